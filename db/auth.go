@@ -14,7 +14,7 @@ func (db *DB) CreateSession(duration time.Duration) (string, error) {
 	token := hex.EncodeToString(bytes)
 	expires := time.Now().Add(duration)
 
-	_, err := db.Conn.Exec(
+	_, err := db.conn().Exec(
 		`INSERT INTO sessions (token, expires_at) VALUES (?, ?)`,
 		token, expires,
 	)
@@ -26,7 +26,7 @@ func (db *DB) CreateSession(duration time.Duration) (string, error) {
 
 func (db *DB) ValidateSession(token string) bool {
 	var count int
-	err := db.Conn.QueryRow(
+	err := db.conn().QueryRow(
 		`SELECT COUNT(*) FROM sessions
 		 WHERE token = ? AND expires_at > ?`,
 		token, time.Now(),
@@ -35,14 +35,14 @@ func (db *DB) ValidateSession(token string) bool {
 }
 
 func (db *DB) DeleteSession(token string) error {
-	_, err := db.Conn.Exec(
+	_, err := db.conn().Exec(
 		`DELETE FROM sessions WHERE token = ?`, token,
 	)
 	return err
 }
 
 func (db *DB) PurgeExpiredSessions() error {
-	_, err := db.Conn.Exec(
+	_, err := db.conn().Exec(
 		`DELETE FROM sessions WHERE expires_at < ?`, time.Now(),
 	)
 	return err
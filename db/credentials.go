@@ -0,0 +1,282 @@
+package db
+
+import (
+	"time"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Certifications ====================
+
+const certificationColumns = `
+		id, name, issuer, issued_date, expiry_date, credential_id,
+		credential_url, deleted, created_at, updated_at,
+		COALESCE(deleted_at, '')`
+
+func scanCertification(sc scanner) (models.Certification, error) {
+	var c models.Certification
+	var deletedAt string
+	if err := sc.Scan(
+		&c.ID, &c.Name, &c.Issuer, &c.IssuedDate, &c.ExpiryDate,
+		&c.CredentialID, &c.CredentialURL, &c.Deleted, &c.CreatedAt,
+		&c.UpdatedAt, &deletedAt,
+	); err != nil {
+		return c, err
+	}
+	if deletedAt != "" {
+		c.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return c, nil
+}
+
+// GetAllCertifications returns every non-deleted certification, most
+// recently issued first.
+func (db *DB) GetAllCertifications() ([]models.Certification, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + certificationColumns + `
+		FROM certifications
+		WHERE deleted = 0
+		ORDER BY issued_date DESC, name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certs []models.Certification
+	for rows.Next() {
+		c, err := scanCertification(rows)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, rows.Err()
+}
+
+func (db *DB) GetCertificationByID(id int64) (*models.Certification, error) {
+	c, err := scanCertification(db.conn().QueryRow(`
+		SELECT`+certificationColumns+`
+		FROM certifications
+		WHERE id = ? AND deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (db *DB) CreateCertification(c models.Certification) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO certifications
+			(name, issuer, issued_date, expiry_date, credential_id, credential_url)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		c.Name, c.Issuer, c.IssuedDate, c.ExpiryDate, c.CredentialID,
+		c.CredentialURL,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdateCertification(c models.Certification) error {
+	_, err := db.conn().Exec(`
+		UPDATE certifications
+		SET name = ?, issuer = ?, issued_date = ?, expiry_date = ?,
+		    credential_id = ?, credential_url = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		c.Name, c.Issuer, c.IssuedDate, c.ExpiryDate, c.CredentialID,
+		c.CredentialURL, c.ID,
+	)
+	return err
+}
+
+func (db *DB) SoftDeleteCertification(id int64) error {
+	_, err := db.conn().Exec(`
+		UPDATE certifications
+		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, id,
+	)
+	return err
+}
+
+// ==================== Awards ====================
+
+const awardColumns = `
+		id, title, issuer, date, description, deleted, created_at,
+		updated_at, COALESCE(deleted_at, '')`
+
+func scanAward(sc scanner) (models.Award, error) {
+	var a models.Award
+	var deletedAt string
+	if err := sc.Scan(
+		&a.ID, &a.Title, &a.Issuer, &a.Date, &a.Description, &a.Deleted,
+		&a.CreatedAt, &a.UpdatedAt, &deletedAt,
+	); err != nil {
+		return a, err
+	}
+	if deletedAt != "" {
+		a.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return a, nil
+}
+
+// GetAllAwards returns every non-deleted award, newest first.
+func (db *DB) GetAllAwards() ([]models.Award, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + awardColumns + `
+		FROM awards
+		WHERE deleted = 0
+		ORDER BY date DESC, title`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var awards []models.Award
+	for rows.Next() {
+		a, err := scanAward(rows)
+		if err != nil {
+			return nil, err
+		}
+		awards = append(awards, a)
+	}
+	return awards, rows.Err()
+}
+
+func (db *DB) GetAwardByID(id int64) (*models.Award, error) {
+	a, err := scanAward(db.conn().QueryRow(`
+		SELECT`+awardColumns+`
+		FROM awards
+		WHERE id = ? AND deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (db *DB) CreateAward(a models.Award) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO awards (title, issuer, date, description)
+		VALUES (?, ?, ?, ?)`,
+		a.Title, a.Issuer, a.Date, a.Description,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdateAward(a models.Award) error {
+	_, err := db.conn().Exec(`
+		UPDATE awards
+		SET title = ?, issuer = ?, date = ?, description = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		a.Title, a.Issuer, a.Date, a.Description, a.ID,
+	)
+	return err
+}
+
+func (db *DB) SoftDeleteAward(id int64) error {
+	_, err := db.conn().Exec(`
+		UPDATE awards
+		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, id,
+	)
+	return err
+}
+
+// ==================== Publications ====================
+
+const publicationColumns = `
+		id, title, kind, venue, date, url, description, deleted,
+		created_at, updated_at, COALESCE(deleted_at, '')`
+
+func scanPublication(sc scanner) (models.Publication, error) {
+	var p models.Publication
+	var deletedAt string
+	if err := sc.Scan(
+		&p.ID, &p.Title, &p.Kind, &p.Venue, &p.Date, &p.URL,
+		&p.Description, &p.Deleted, &p.CreatedAt, &p.UpdatedAt, &deletedAt,
+	); err != nil {
+		return p, err
+	}
+	if deletedAt != "" {
+		p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return p, nil
+}
+
+// GetAllPublications returns every non-deleted publication and talk,
+// newest first.
+func (db *DB) GetAllPublications() ([]models.Publication, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + publicationColumns + `
+		FROM publications
+		WHERE deleted = 0
+		ORDER BY date DESC, title`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pubs []models.Publication
+	for rows.Next() {
+		p, err := scanPublication(rows)
+		if err != nil {
+			return nil, err
+		}
+		pubs = append(pubs, p)
+	}
+	return pubs, rows.Err()
+}
+
+func (db *DB) GetPublicationByID(id int64) (*models.Publication, error) {
+	p, err := scanPublication(db.conn().QueryRow(`
+		SELECT`+publicationColumns+`
+		FROM publications
+		WHERE id = ? AND deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (db *DB) CreatePublication(p models.Publication) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO publications (title, kind, venue, date, url, description)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		p.Title, p.Kind, p.Venue, p.Date, p.URL, p.Description,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdatePublication(p models.Publication) error {
+	_, err := db.conn().Exec(`
+		UPDATE publications
+		SET title = ?, kind = ?, venue = ?, date = ?, url = ?,
+		    description = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		p.Title, p.Kind, p.Venue, p.Date, p.URL, p.Description, p.ID,
+	)
+	return err
+}
+
+func (db *DB) SoftDeletePublication(id int64) error {
+	_, err := db.conn().Exec(`
+		UPDATE publications
+		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, id,
+	)
+	return err
+}
@@ -2,8 +2,10 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/DYankee/resume2/models"
@@ -13,6 +15,78 @@ import (
 
 type DB struct {
 	Conn *sql.DB
+	// SearchEnabled is false when SQLite was built without FTS5; Search
+	// then finds nothing.
+	SearchEnabled bool
+
+	tx *sql.Tx // set on the DB passed to a WithTx callback
+}
+
+// execer runs statements: the connection, or the transaction of WithTx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func (db *DB) conn() execer {
+	if db.tx != nil {
+		return db.tx
+	}
+	return db.Conn
+}
+
+// WithTx runs fn with a DB whose methods all use one transaction. It is
+// committed if fn returns nil and rolled back otherwise. Transactions the
+// methods begin themselves become part of it.
+func (db *DB) WithTx(fn func(*DB) error) error {
+	if db.tx != nil {
+		return fn(db)
+	}
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+	inner := *db
+	inner.tx = tx
+	if err := fn(&inner); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// txn is a transaction begun by a DB method. Inside WithTx it is the
+// surrounding transaction, and Commit and Rollback leave ending it to
+// WithTx.
+type txn struct {
+	*sql.Tx
+	nested bool
+}
+
+func (db *DB) begin() (*txn, error) {
+	if db.tx != nil {
+		return &txn{Tx: db.tx, nested: true}, nil
+	}
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &txn{Tx: tx}, nil
+}
+
+func (t *txn) Commit() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txn) Rollback() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Rollback()
 }
 
 func New(path string) *DB {
@@ -45,12 +119,21 @@ func (db *DB) migrate() {
 			description TEXT NOT NULL DEFAULT '',
 			icon_url TEXT NOT NULL DEFAULT '',
 			proficiency INTEGER NOT NULL DEFAULT 50,
+			visibility TEXT NOT NULL DEFAULT 'public',
 			deleted INTEGER NOT NULL DEFAULT 0,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			deleted_at DATETIME,
 			FOREIGN KEY (category_id) REFERENCES skill_categories(id)
 		)`,
+		`CREATE TABLE IF NOT EXISTS skill_aliases (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			skill_id INTEGER NOT NULL,
+			alias TEXT NOT NULL,
+			normalized TEXT NOT NULL,
+			FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE,
+			UNIQUE(skill_id, normalized)
+		)`,
 		`CREATE TABLE IF NOT EXISTS projects (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			display_order INTEGER DEFAULT 0,
@@ -60,6 +143,8 @@ func (db *DB) migrate() {
 			image_url TEXT NOT NULL DEFAULT '',
 			repo_url TEXT NOT NULL DEFAULT '',
 			live_url TEXT NOT NULL DEFAULT '',
+			visibility TEXT NOT NULL DEFAULT 'public',
+			slug TEXT NOT NULL DEFAULT '',
 			deleted INTEGER NOT NULL DEFAULT 0,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -73,6 +158,17 @@ func (db *DB) migrate() {
 			FOREIGN KEY (project_id) REFERENCES projects(id),
 			UNIQUE(skill_id, project_id)
 		)`,
+		`CREATE TABLE IF NOT EXISTS project_media (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL,
+			kind TEXT NOT NULL DEFAULT 'image',
+			url TEXT NOT NULL,
+			caption TEXT NOT NULL DEFAULT '',
+			alt_text TEXT NOT NULL DEFAULT '',
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (project_id) REFERENCES projects(id)
+		)`,
 		`CREATE TABLE IF NOT EXISTS experiences (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			title TEXT NOT NULL,
@@ -85,6 +181,22 @@ func (db *DB) migrate() {
 			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			deleted_at DATETIME
 		)`,
+		`CREATE TABLE IF NOT EXISTS experience_highlights (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			experience_id INTEGER NOT NULL,
+			text TEXT NOT NULL,
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (experience_id) REFERENCES experiences(id)
+				ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS experience_skills (
+			experience_id INTEGER NOT NULL,
+			skill_id INTEGER NOT NULL,
+			PRIMARY KEY (experience_id, skill_id),
+			FOREIGN KEY (experience_id) REFERENCES experiences(id)
+				ON DELETE CASCADE,
+			FOREIGN KEY (skill_id) REFERENCES skills(id)
+		)`,
 		`CREATE TABLE IF NOT EXISTS blog_posts (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			title TEXT NOT NULL,
@@ -105,18 +217,250 @@ func (db *DB) migrate() {
     		gpa REAL NOT NULL DEFAULT 0.0,
     		in_progress INTEGER NOT NULL DEFAULT 0
 		)`,
+		`CREATE TABLE IF NOT EXISTS education_courses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			education_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (education_id) REFERENCES education(id)
+				ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS education_projects (
+			education_id INTEGER NOT NULL,
+			project_id INTEGER NOT NULL,
+			PRIMARY KEY (education_id, project_id),
+			FOREIGN KEY (education_id) REFERENCES education(id)
+				ON DELETE CASCADE,
+			FOREIGN KEY (project_id) REFERENCES projects(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS certifications (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			issuer TEXT NOT NULL DEFAULT '',
+			issued_date TEXT NOT NULL DEFAULT '',
+			expiry_date TEXT NOT NULL DEFAULT '',
+			credential_id TEXT NOT NULL DEFAULT '',
+			credential_url TEXT NOT NULL DEFAULT '',
+			deleted INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+		`CREATE TABLE IF NOT EXISTS awards (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			issuer TEXT NOT NULL DEFAULT '',
+			date TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			deleted INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+		`CREATE TABLE IF NOT EXISTS publications (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			kind TEXT NOT NULL DEFAULT 'publication',
+			venue TEXT NOT NULL DEFAULT '',
+			date TEXT NOT NULL DEFAULT '',
+			url TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			deleted INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+		`CREATE TABLE IF NOT EXISTS custom_sections (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			body TEXT NOT NULL DEFAULT '',
+			deleted INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+		`CREATE TABLE IF NOT EXISTS resume_projects (
+			project_id INTEGER PRIMARY KEY,
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (project_id) REFERENCES projects(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS resume_variants (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			slug TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			headline TEXT NOT NULL DEFAULT '',
+			summary TEXT NOT NULL DEFAULT '',
+			sections TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS resume_variant_items (
+			variant_id INTEGER NOT NULL,
+			kind TEXT NOT NULL,
+			item_id INTEGER NOT NULL,
+			PRIMARY KEY (variant_id, kind, item_id),
+			FOREIGN KEY (variant_id) REFERENCES resume_variants(id)
+				ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS profile (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			name TEXT NOT NULL DEFAULT '',
+			headline TEXT NOT NULL DEFAULT '',
+			email TEXT NOT NULL DEFAULT '',
+			phone TEXT NOT NULL DEFAULT '',
+			location TEXT NOT NULL DEFAULT '',
+			linkedin_url TEXT NOT NULL DEFAULT '',
+			github_url TEXT NOT NULL DEFAULT '',
+			website_url TEXT NOT NULL DEFAULT '',
+			summary TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE
+		)`,
+		`CREATE TABLE IF NOT EXISTS taggings (
+			tag_id INTEGER NOT NULL,
+			kind TEXT NOT NULL,
+			item_id INTEGER NOT NULL,
+			sort_order INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (tag_id, kind, item_id),
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS preview_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL,
+			item_id INTEGER NOT NULL,
+			expires_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
 	}
 	for _, q := range queries {
-		if _, err := db.Conn.Exec(q); err != nil {
+		if _, err := db.conn().Exec(q); err != nil {
+			log.Fatalf("migration failed: %v\n%s", err, q)
+		}
+	}
+
+	// Columns added after the tables above were first created. SQLite
+	// has no ADD COLUMN IF NOT EXISTS, so addColumn checks first.
+	columns := []struct{ table, column, def string }{
+		{"skill_categories", "sort_order", "INTEGER NOT NULL DEFAULT 0"},
+		{"blog_posts", "publish_at", "DATETIME"},
+		{"blog_posts", "unpublish_at", "DATETIME"},
+		{"skills", "visibility", "TEXT NOT NULL DEFAULT 'public'"},
+		{"projects", "visibility", "TEXT NOT NULL DEFAULT 'public'"},
+		{"projects", "slug", "TEXT NOT NULL DEFAULT ''"},
+		{"experiences", "location", "TEXT NOT NULL DEFAULT ''"},
+		{"experiences", "employment_type", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "major", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "minor", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "start_date", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "end_date", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "honors", "TEXT NOT NULL DEFAULT ''"},
+		{"education", "deleted", "INTEGER NOT NULL DEFAULT 0"},
+		{"education", "updated_at", "DATETIME"},
+		{"education", "deleted_at", "DATETIME"},
+	}
+	for _, c := range columns {
+		if err := db.addColumn(c.table, c.column, c.def); err != nil {
+			log.Fatalf("migration failed: %v\n%s.%s", err, c.table, c.column)
+		}
+	}
+
+	if err := db.ensureProfile(); err != nil {
+		log.Fatalf("migration failed: %v\ncreate profile", err)
+	}
+
+	if err := db.backfillProjectSlugs(); err != nil {
+		log.Fatalf("migration failed: %v\nbackfill project slugs", err)
+	}
+
+	if err := db.normalizeExperienceDates(); err != nil {
+		log.Fatalf("migration failed: %v\nnormalize experience dates", err)
+	}
+
+	indexes := []string{
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_slug
+			ON projects(slug)`,
+		`CREATE INDEX IF NOT EXISTS idx_taggings_item
+			ON taggings(kind, item_id)`,
+	}
+	for _, q := range indexes {
+		if _, err := db.conn().Exec(q); err != nil {
 			log.Fatalf("migration failed: %v\n%s", err, q)
 		}
 	}
+
+	if err := db.migrateTags(); err != nil {
+		log.Fatalf("migration failed: %v\nmigrate tags", err)
+	}
+
+	if err := db.migrateSearch(); err != nil {
+		log.Fatalf("migration failed: %v", err)
+	}
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, for use with
+// ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// addColumn adds column to table unless it already exists.
+func (db *DB) addColumn(table, column, def string) error {
+	rows, err := db.conn().Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.conn().Exec(
+		`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + def,
+	)
+	return err
 }
 
 // ==================== Skill Categories ====================
 
+// ErrCategoryInUse is returned when deleting a category that skills are
+// still filed under.
+var ErrCategoryInUse = errors.New("category has skills")
+
+const skillCategoryColumns = `
+		sc.id, sc.name, sc.sort_order,
+		(SELECT COUNT(*) FROM skills s
+		 WHERE s.category_id = sc.id AND s.deleted = 0)`
+
+func scanSkillCategory(sc scanner) (models.Skill_category, error) {
+	var c models.Skill_category
+	err := sc.Scan(&c.ID, &c.Name, &c.SortOrder, &c.Skills)
+	return c, err
+}
+
+// GetAllSkillCategories returns every category in the admin's chosen
+// order. Categories never reordered sort by name.
 func (db *DB) GetAllSkillCategories() ([]models.Skill_category, error) {
-	rows, err := db.Conn.Query(`SELECT id, name FROM skill_categories ORDER BY name`)
+	rows, err := db.conn().Query(`
+		SELECT` + skillCategoryColumns + `
+		FROM skill_categories sc
+		ORDER BY sc.sort_order, sc.name`,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -124,93 +468,169 @@ func (db *DB) GetAllSkillCategories() ([]models.Skill_category, error) {
 
 	var cats []models.Skill_category
 	for rows.Next() {
-		var c models.Skill_category
-		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+		c, err := scanSkillCategory(rows)
+		if err != nil {
 			return nil, err
 		}
 		cats = append(cats, c)
 	}
-	return cats, nil
+	return cats, rows.Err()
+}
+
+func (db *DB) GetSkillCategoryByID(id int64) (*models.Skill_category, error) {
+	c, err := scanSkillCategory(db.conn().QueryRow(`
+		SELECT`+skillCategoryColumns+`
+		FROM skill_categories sc
+		WHERE sc.id = ?`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
 
+// CreateSkillCategory adds a category at the end of the order.
 func (db *DB) CreateSkillCategory(name string) (int64, error) {
-	res, err := db.Conn.Exec(`INSERT INTO skill_categories (name) VALUES (?)`, name)
+	res, err := db.conn().Exec(`
+		INSERT INTO skill_categories (name, sort_order)
+		VALUES (?, (
+			SELECT COALESCE(MAX(sort_order), 0) + 1 FROM skill_categories
+		))`, name,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return res.LastInsertId()
 }
 
-// ==================== Skills ====================
-
-func (db *DB) GetAllSkills() ([]models.Skill, error) {
-	rows, err := db.Conn.Query(`
-		SELECT s.id, s.name, sc.name, s.description, s.icon_url,
-		       s.proficiency, s.deleted, s.created_at, s.updated_at,
-		       COALESCE(s.deleted_at, '')
-		FROM skills s
-		JOIN skill_categories sc ON s.category_id = sc.id
-		WHERE s.deleted = 0
-		ORDER BY s.proficiency DESC`,
+func (db *DB) UpdateSkillCategory(id int64, name string) error {
+	_, err := db.conn().Exec(
+		`UPDATE skill_categories SET name = ? WHERE id = ?`, name, id,
 	)
+	return err
+}
+
+// SkillCategoryNameTaken reports whether another category already uses
+// name, ignoring case.
+func (db *DB) SkillCategoryNameTaken(name string, excludeID int64) (bool, error) {
+	var n int
+	err := db.conn().QueryRow(`
+		SELECT COUNT(*) FROM skill_categories
+		WHERE name = ? COLLATE NOCASE AND id != ?`, name, excludeID,
+	).Scan(&n)
+	return n > 0, err
+}
+
+// DeleteSkillCategory removes a category. It fails with
+// ErrCategoryInUse while any skill, deleted ones included, is filed
+// under it; MergeSkillCategories moves them out first.
+func (db *DB) DeleteSkillCategory(id int64) error {
+	var n int
+	if err := db.conn().QueryRow(
+		`SELECT COUNT(*) FROM skills WHERE category_id = ?`, id,
+	).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrCategoryInUse
+	}
+	_, err := db.conn().Exec(`DELETE FROM skill_categories WHERE id = ?`, id)
+	return err
+}
+
+// MergeSkillCategories moves every skill in category from, deleted ones
+// included, into category into and removes from, all or nothing.
+func (db *DB) MergeSkillCategories(from, into int64) error {
+	tx, err := db.begin()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
+	if _, err := tx.Exec(
+		`UPDATE skills SET category_id = ? WHERE category_id = ?`, into, from,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		`DELETE FROM skill_categories WHERE id = ?`, from,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	var skills []models.Skill
-	for rows.Next() {
-		var s models.Skill
-		var deletedAt string
-		if err := rows.Scan(
-			&s.ID, &s.Name, &s.Category, &s.Description, &s.IconURL,
-			&s.Proficiency, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
-			&deletedAt,
-		); err != nil {
-			return nil, err
+// MoveSkillCategory swaps a category with its neighbour: up when delta
+// is negative, down otherwise. Moving past either end is a no-op.
+func (db *DB) MoveSkillCategory(id int64, delta int) error {
+	cats, err := db.GetAllSkillCategories()
+	if err != nil {
+		return err
+	}
+	for i, c := range cats {
+		if c.ID != id {
+			continue
 		}
-		if deletedAt != "" {
-			s.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+		j := i + 1
+		if delta < 0 {
+			j = i - 1
 		}
-		skills = append(skills, s)
+		if j < 0 || j >= len(cats) {
+			return nil
+		}
+		cats[i], cats[j] = cats[j], cats[i]
+		break
 	}
-	return skills, nil
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i, c := range cats {
+		if _, err := tx.Exec(
+			`UPDATE skill_categories SET sort_order = ? WHERE id = ?`,
+			i+1, c.ID,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (db *DB) GetSkillByID(id int64) (*models.Skill, error) {
+// ==================== Skills ====================
+
+// skillColumns is the column list every skill query selects; keep it in
+// step with scanSkill.
+const skillColumns = `
+		s.id, s.name, sc.name, s.description, s.icon_url,
+		s.proficiency, s.visibility, s.deleted, s.created_at, s.updated_at,
+		COALESCE(s.deleted_at, '')`
+
+// listedSkill matches skills that appear in public listings.
+const listedSkill = `s.visibility IN ('public', 'featured')`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSkill(sc scanner) (models.Skill, error) {
 	var s models.Skill
 	var deletedAt string
-	err := db.Conn.QueryRow(`
-		SELECT s.id, s.name, sc.name, s.description, s.icon_url,
-		       s.proficiency, s.deleted, s.created_at, s.updated_at,
-		       COALESCE(s.deleted_at, '')
-		FROM skills s
-		JOIN skill_categories sc ON s.category_id = sc.id
-		WHERE s.id = ? AND s.deleted = 0`, id,
-	).Scan(
+	if err := sc.Scan(
 		&s.ID, &s.Name, &s.Category, &s.Description, &s.IconURL,
-		&s.Proficiency, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
+		&s.Proficiency, &s.Visibility, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
 		&deletedAt,
-	)
-	if err != nil {
-		return nil, err
+	); err != nil {
+		return s, err
 	}
 	if deletedAt != "" {
 		s.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
 	}
-	return &s, nil
+	return s, nil
 }
 
-func (db *DB) GetSkillsByCategoryID(categoryID int64) ([]models.Skill, error) {
-	rows, err := db.Conn.Query(`
-		SELECT s.id, s.name, sc.name, s.description, s.icon_url,
-		       s.proficiency, s.deleted, s.created_at, s.updated_at,
-		       COALESCE(s.deleted_at, '')
-		FROM skills s
-		JOIN skill_categories sc ON s.category_id = sc.id
-		WHERE s.category_id = ? AND s.deleted = 0
-		ORDER BY s.name`, categoryID,
-	)
+func (db *DB) querySkills(query string, args ...any) ([]models.Skill, error) {
+	rows, err := db.conn().Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,36 +638,80 @@ func (db *DB) GetSkillsByCategoryID(categoryID int64) ([]models.Skill, error) {
 
 	var skills []models.Skill
 	for rows.Next() {
-		var s models.Skill
-		var deletedAt string
-		if err := rows.Scan(
-			&s.ID, &s.Name, &s.Category, &s.Description, &s.IconURL,
-			&s.Proficiency, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
-			&deletedAt,
-		); err != nil {
+		s, err := scanSkill(rows)
+		if err != nil {
 			return nil, err
 		}
-		if deletedAt != "" {
-			s.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
 		skills = append(skills, s)
 	}
-	return skills, nil
+	return skills, rows.Err()
+}
+
+// GetAllSkills returns every non-deleted skill regardless of visibility.
+func (db *DB) GetAllSkills() ([]models.Skill, error) {
+	return db.querySkills(`
+		SELECT` + skillColumns + `
+		FROM skills s
+		JOIN skill_categories sc ON s.category_id = sc.id
+		WHERE s.deleted = 0
+		ORDER BY s.proficiency DESC`,
+	)
+}
+
+// GetListedSkills returns the public and featured skills, featured first.
+func (db *DB) GetListedSkills() ([]models.Skill, error) {
+	return db.querySkills(`
+		SELECT` + skillColumns + `
+		FROM skills s
+		JOIN skill_categories sc ON s.category_id = sc.id
+		WHERE s.deleted = 0 AND ` + listedSkill + `
+		ORDER BY s.visibility = 'featured' DESC, s.proficiency DESC`,
+	)
+}
+
+func (db *DB) GetSkillByID(id int64) (*models.Skill, error) {
+	s, err := scanSkill(db.conn().QueryRow(`
+		SELECT`+skillColumns+`
+		FROM skills s
+		JOIN skill_categories sc ON s.category_id = sc.id
+		WHERE s.id = ? AND s.deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
 }
 
+// GetListedSkillsByCategoryID returns the public and featured skills in
+// one category, featured first.
+func (db *DB) GetListedSkillsByCategoryID(categoryID int64) ([]models.Skill, error) {
+	return db.querySkills(`
+		SELECT`+skillColumns+`
+		FROM skills s
+		JOIN skill_categories sc ON s.category_id = sc.id
+		WHERE s.category_id = ? AND s.deleted = 0 AND `+listedSkill+`
+		ORDER BY s.visibility = 'featured' DESC, s.name`, categoryID,
+	)
+}
+
+// GetRandomProjectForSkill picks one listed project that uses the skill.
 func (db *DB) GetRandomProjectForSkill(skillID int64) (*models.Project, error) {
 	projects, err := db.GetProjectsForSkill(skillID)
-	if err != nil || len(projects) == 0 {
+	if err != nil {
 		return nil, err
 	}
+	projects = ListedProjects(projects)
+	if len(projects) == 0 {
+		return nil, nil
+	}
 	return &projects[rand.Intn(len(projects))], nil
 }
 
-func (db *DB) CreateSkill(name string, categoryID int64, description, iconURL string, proficiency int8) (int64, error) {
-	res, err := db.Conn.Exec(`
-		INSERT INTO skills (name, category_id, description, icon_url, proficiency)
-		VALUES (?, ?, ?, ?, ?)`,
-		name, categoryID, description, iconURL, proficiency,
+func (db *DB) CreateSkill(name string, categoryID int64, description, iconURL string, proficiency int8, visibility string) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO skills (name, category_id, description, icon_url, proficiency, visibility)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		name, categoryID, description, iconURL, proficiency, visibility,
 	)
 	if err != nil {
 		return 0, err
@@ -255,19 +719,19 @@ func (db *DB) CreateSkill(name string, categoryID int64, description, iconURL st
 	return res.LastInsertId()
 }
 
-func (db *DB) UpdateSkill(id int64, name string, categoryID int64, description, iconURL string, proficiency int8) error {
-	_, err := db.Conn.Exec(`
+func (db *DB) UpdateSkill(id int64, name string, categoryID int64, description, iconURL string, proficiency int8, visibility string) error {
+	_, err := db.conn().Exec(`
 		UPDATE skills
 		SET name = ?, category_id = ?, description = ?, icon_url = ?,
-		    proficiency = ?, updated_at = CURRENT_TIMESTAMP
+		    proficiency = ?, visibility = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND deleted = 0`,
-		name, categoryID, description, iconURL, proficiency, id,
+		name, categoryID, description, iconURL, proficiency, visibility, id,
 	)
 	return err
 }
 
 func (db *DB) SoftDeleteSkill(id int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		UPDATE skills
 		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`, id,
@@ -275,16 +739,47 @@ func (db *DB) SoftDeleteSkill(id int64) error {
 	return err
 }
 
+// ListedSkills drops unlisted and private skills, keeping the order.
+func ListedSkills(skills []models.Skill) []models.Skill {
+	var listed []models.Skill
+	for _, s := range skills {
+		if s.Listed() {
+			listed = append(listed, s)
+		}
+	}
+	return listed
+}
+
 // ==================== Projects ====================
 
-func (db *DB) GetAllProjects() ([]models.Project, error) {
-	rows, err := db.Conn.Query(`
-		SELECT id, title, description, long_desc, image_url, repo_url, live_url,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
-		FROM projects
-		WHERE deleted = 0
-		ORDER BY created_at DESC`,
-	)
+// projectColumns is the column list every project query selects; keep
+// it in step with scanProject.
+const projectColumns = `
+		p.id, p.slug, p.title, p.description, p.long_desc, p.image_url,
+		p.repo_url, p.live_url, p.visibility, p.deleted, p.created_at,
+		p.updated_at, COALESCE(p.deleted_at, '')`
+
+// listedProject matches projects that appear in public listings.
+const listedProject = `p.visibility IN ('public', 'featured')`
+
+func scanProject(sc scanner) (models.Project, error) {
+	var p models.Project
+	var deletedAt string
+	if err := sc.Scan(
+		&p.ID, &p.Slug, &p.Title, &p.Description, &p.LongDesc, &p.ImageURL,
+		&p.RepoURL, &p.LiveURL, &p.Visibility, &p.Deleted, &p.CreatedAt,
+		&p.UpdatedAt, &deletedAt,
+	); err != nil {
+		return p, err
+	}
+	if deletedAt != "" {
+		p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return p, nil
+}
+
+func (db *DB) queryProjects(query string, args ...any) ([]models.Project, error) {
+	rows, err := db.conn().Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -292,70 +787,163 @@ func (db *DB) GetAllProjects() ([]models.Project, error) {
 
 	var projects []models.Project
 	for rows.Next() {
-		var p models.Project
-		var deletedAt string
-		if err := rows.Scan(
-			&p.ID, &p.Title, &p.Description, &p.LongDesc, &p.ImageURL,
-			&p.RepoURL, &p.LiveURL, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-			&deletedAt,
-		); err != nil {
+		p, err := scanProject(rows)
+		if err != nil {
 			return nil, err
 		}
-		if deletedAt != "" {
-			p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
 		projects = append(projects, p)
 	}
-	return projects, nil
+	return projects, rows.Err()
 }
 
-func (db *DB) GetProjectByID(id int64) (*models.Project, error) {
-	var p models.Project
-	var deletedAt string
-	err := db.Conn.QueryRow(`
-		SELECT id, title, description, long_desc, image_url, repo_url, live_url,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
-		FROM projects
-		WHERE id = ? AND deleted = 0`, id,
-	).Scan(
-		&p.ID, &p.Title, &p.Description, &p.LongDesc, &p.ImageURL,
-		&p.RepoURL, &p.LiveURL, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-		&deletedAt,
+// GetAllProjects returns every non-deleted project regardless of
+// visibility.
+func (db *DB) GetAllProjects() ([]models.Project, error) {
+	return db.queryProjects(`
+		SELECT` + projectColumns + `
+		FROM projects p
+		WHERE p.deleted = 0
+		ORDER BY p.created_at DESC`,
 	)
-	if err != nil {
-		return nil, err
-	}
-	if deletedAt != "" {
-		p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-	}
-	return &p, nil
 }
 
-func (db *DB) CreateProject(title, description, longDesc, imageURL, repoURL, liveURL string) (int64, error) {
-	res, err := db.Conn.Exec(`
-		INSERT INTO projects (title, description, long_desc, image_url, repo_url, live_url)
-		VALUES (?, ?, ?, ?, ?, ?)`,
-		title, description, longDesc, imageURL, repoURL, liveURL,
+// GetListedProjects returns the public and featured projects, featured
+// first.
+func (db *DB) GetListedProjects() ([]models.Project, error) {
+	return db.queryProjects(`
+		SELECT` + projectColumns + `
+		FROM projects p
+		WHERE p.deleted = 0 AND ` + listedProject + `
+		ORDER BY p.visibility = 'featured' DESC, p.created_at DESC`,
 	)
-	if err != nil {
-		return 0, err
-	}
-	return res.LastInsertId()
 }
 
-func (db *DB) UpdateProject(id int64, title, description, longDesc, imageURL, repoURL, liveURL string) error {
-	_, err := db.Conn.Exec(`
-		UPDATE projects
-		SET title = ?, description = ?, long_desc = ?, image_url = ?,
-		    repo_url = ?, live_url = ?, updated_at = CURRENT_TIMESTAMP
+// FilterListedProjects returns the listed projects matching f, in the
+// same order as GetListedProjects.
+func (db *DB) FilterListedProjects(f models.ProjectFilter) ([]models.Project, error) {
+	query := `
+		SELECT` + projectColumns + `
+		FROM projects p
+		WHERE p.deleted = 0 AND ` + listedProject
+	var args []any
+
+	if len(f.SkillIDs) > 0 {
+		placeholders := strings.TrimSuffix(
+			strings.Repeat("?, ", len(f.SkillIDs)), ", ",
+		)
+		query += `
+		AND p.id IN (
+			SELECT su.project_id FROM skill_uses su
+			WHERE su.skill_id IN (` + placeholders + `)
+			GROUP BY su.project_id
+			HAVING COUNT(DISTINCT su.skill_id) = ?
+		)`
+		for _, id := range f.SkillIDs {
+			args = append(args, id)
+		}
+		args = append(args, len(f.SkillIDs))
+	}
+	if f.CategoryID != 0 {
+		query += `
+		AND EXISTS (
+			SELECT 1 FROM skill_uses su
+			JOIN skills s ON s.id = su.skill_id
+			WHERE su.project_id = p.id AND s.category_id = ?
+			  AND s.deleted = 0
+		)`
+		args = append(args, f.CategoryID)
+	}
+	if f.Query != "" {
+		like := "%" + likeEscaper.Replace(f.Query) + "%"
+		query += `
+		AND (p.title LIKE ? ESCAPE '\' OR p.description LIKE ? ESCAPE '\'
+		     OR p.long_desc LIKE ? ESCAPE '\')`
+		args = append(args, like, like, like)
+	}
+
+	query += `
+		ORDER BY p.visibility = 'featured' DESC, p.created_at DESC`
+	return db.queryProjects(query, args...)
+}
+
+// GetFeaturedProjects returns only the featured projects.
+func (db *DB) GetFeaturedProjects() ([]models.Project, error) {
+	return db.queryProjects(`
+		SELECT` + projectColumns + `
+		FROM projects p
+		WHERE p.deleted = 0 AND p.visibility = 'featured'
+		ORDER BY p.created_at DESC`,
+	)
+}
+
+func (db *DB) GetProjectByID(id int64) (*models.Project, error) {
+	p, err := scanProject(db.conn().QueryRow(`
+		SELECT`+projectColumns+`
+		FROM projects p
+		WHERE p.id = ? AND p.deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if p.Tags, err = db.GetTags(models.TagKindProject, p.ID); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetProjectBySlug looks up a non-deleted project by its URL slug.
+func (db *DB) GetProjectBySlug(slug string) (*models.Project, error) {
+	p, err := scanProject(db.conn().QueryRow(`
+		SELECT`+projectColumns+`
+		FROM projects p
+		WHERE p.slug = ? AND p.deleted = 0`, slug,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if p.Tags, err = db.GetTags(models.TagKindProject, p.ID); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateProject inserts a project. An empty slug is derived from the
+// title; either way it is made unique before saving.
+func (db *DB) CreateProject(slug, title, description, longDesc, imageURL, repoURL, liveURL, visibility string) (int64, error) {
+	slug, err := db.uniqueProjectSlug(slug, title, 0)
+	if err != nil {
+		return 0, err
+	}
+	res, err := db.conn().Exec(`
+		INSERT INTO projects (slug, title, description, long_desc, image_url, repo_url, live_url, visibility)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		slug, title, description, longDesc, imageURL, repoURL, liveURL, visibility,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdateProject(id int64, slug, title, description, longDesc, imageURL, repoURL, liveURL, visibility string) error {
+	slug, err := db.uniqueProjectSlug(slug, title, id)
+	if err != nil {
+		return err
+	}
+	_, err = db.conn().Exec(`
+		UPDATE projects
+		SET slug = ?, title = ?, description = ?, long_desc = ?,
+		    image_url = ?, repo_url = ?, live_url = ?, visibility = ?,
+		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND deleted = 0`,
-		title, description, longDesc, imageURL, repoURL, liveURL, id,
+		slug, title, description, longDesc, imageURL, repoURL, liveURL,
+		visibility, id,
 	)
 	return err
 }
 
 func (db *DB) SoftDeleteProject(id int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		UPDATE projects
 		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`, id,
@@ -363,10 +951,21 @@ func (db *DB) SoftDeleteProject(id int64) error {
 	return err
 }
 
+// ListedProjects drops unlisted and private projects, keeping the order.
+func ListedProjects(projects []models.Project) []models.Project {
+	var listed []models.Project
+	for _, p := range projects {
+		if p.Listed() {
+			listed = append(listed, p)
+		}
+	}
+	return listed
+}
+
 // ==================== Skill Uses (Project <-> Skill) ====================
 
 func (db *DB) AddSkillToProject(skillID, projectID int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		INSERT OR IGNORE INTO skill_uses (skill_id, project_id)
 		VALUES (?, ?)`, skillID, projectID,
 	)
@@ -374,88 +973,88 @@ func (db *DB) AddSkillToProject(skillID, projectID int64) error {
 }
 
 func (db *DB) RemoveSkillFromProject(skillID, projectID int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		DELETE FROM skill_uses WHERE skill_id = ? AND project_id = ?`,
 		skillID, projectID,
 	)
 	return err
 }
 
-func (db *DB) GetSkillsForProject(projectID int64) ([]models.Skill, error) {
-	rows, err := db.Conn.Query(`
-		SELECT s.id, s.name, sc.name, s.description, s.icon_url,
-		       s.proficiency, s.deleted, s.created_at, s.updated_at,
-		       COALESCE(s.deleted_at, '')
-		FROM skills s
-		JOIN skill_categories sc ON s.category_id = sc.id
-		JOIN skill_uses su ON su.skill_id = s.id
-		WHERE su.project_id = ? AND s.deleted = 0
-		ORDER BY s.name`, projectID,
+// GetAllSkillUses returns every link between a non-deleted skill and a
+// non-deleted project.
+func (db *DB) GetAllSkillUses() ([]models.SkillUse, error) {
+	rows, err := db.conn().Query(`
+		SELECT su.id, su.skill_id, su.project_id
+		FROM skill_uses su
+		JOIN skills s ON s.id = su.skill_id
+		JOIN projects p ON p.id = su.project_id
+		WHERE s.deleted = 0 AND p.deleted = 0
+		ORDER BY su.id`,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var skills []models.Skill
+	var uses []models.SkillUse
 	for rows.Next() {
-		var s models.Skill
-		var deletedAt string
-		if err := rows.Scan(
-			&s.ID, &s.Name, &s.Category, &s.Description, &s.IconURL,
-			&s.Proficiency, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
-			&deletedAt,
-		); err != nil {
+		var u models.SkillUse
+		if err := rows.Scan(&u.ID, &u.Skill_ID, &u.Project_ID); err != nil {
 			return nil, err
 		}
-		if deletedAt != "" {
-			s.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
-		skills = append(skills, s)
+		uses = append(uses, u)
 	}
-	return skills, nil
+	return uses, rows.Err()
+}
+
+func (db *DB) GetSkillsForProject(projectID int64) ([]models.Skill, error) {
+	return db.querySkills(`
+		SELECT`+skillColumns+`
+		FROM skills s
+		JOIN skill_categories sc ON s.category_id = sc.id
+		JOIN skill_uses su ON su.skill_id = s.id
+		WHERE su.project_id = ? AND s.deleted = 0
+		ORDER BY s.name`, projectID,
+	)
 }
 
 func (db *DB) GetProjectsForSkill(skillID int64) ([]models.Project, error) {
-	rows, err := db.Conn.Query(`
-		SELECT p.id, p.title, p.description, p.long_desc, p.image_url,
-		       p.repo_url, p.live_url, p.deleted, p.created_at, p.updated_at,
-		       COALESCE(p.deleted_at, '')
+	return db.queryProjects(`
+		SELECT`+projectColumns+`
 		FROM projects p
 		JOIN skill_uses su ON su.project_id = p.id
 		WHERE su.skill_id = ? AND p.deleted = 0
 		ORDER BY p.created_at DESC`, skillID,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var projects []models.Project
-	for rows.Next() {
-		var p models.Project
-		var deletedAt string
-		if err := rows.Scan(
-			&p.ID, &p.Title, &p.Description, &p.LongDesc, &p.ImageURL,
-			&p.RepoURL, &p.LiveURL, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-			&deletedAt,
-		); err != nil {
-			return nil, err
-		}
-		if deletedAt != "" {
-			p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
-		projects = append(projects, p)
-	}
-	return projects, nil
 }
 
 // ==================== Experiences ====================
 
+const experienceColumns = `
+		id, title, company, location, employment_type, start_date, end_date,
+		description, deleted, created_at, updated_at, COALESCE(deleted_at, '')`
+
+func scanExperience(sc scanner) (models.Experience, error) {
+	var e models.Experience
+	var deletedAt string
+	if err := sc.Scan(
+		&e.ID, &e.Title, &e.Company, &e.Location, &e.EmploymentType,
+		&e.StartDate, &e.EndDate, &e.Description, &e.Deleted,
+		&e.CreatedAt, &e.UpdatedAt, &deletedAt,
+	); err != nil {
+		return e, err
+	}
+	if deletedAt != "" {
+		e.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return e, nil
+}
+
+// GetAllExperiences returns every non-deleted experience, newest first,
+// with its highlights and linked skills (of any visibility).
 func (db *DB) GetAllExperiences() ([]models.Experience, error) {
-	rows, err := db.Conn.Query(`
-		SELECT id, title, company, start_date, end_date, description,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
+	rows, err := db.conn().Query(`
+		SELECT` + experienceColumns + `
 		FROM experiences
 		WHERE deleted = 0
 		ORDER BY start_date DESC`,
@@ -463,72 +1062,209 @@ func (db *DB) GetAllExperiences() ([]models.Experience, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
 	var exps []models.Experience
 	for rows.Next() {
-		var e models.Experience
-		var deletedAt string
-		if err := rows.Scan(
-			&e.ID, &e.Title, &e.Company, &e.StartDate, &e.EndDate,
-			&e.Description, &e.Deleted, &e.CreatedAt, &e.UpdatedAt,
-			&deletedAt,
-		); err != nil {
+		e, err := scanExperience(rows)
+		if err != nil {
+			rows.Close()
 			return nil, err
 		}
-		if deletedAt != "" {
-			e.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
 		exps = append(exps, e)
 	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := db.loadExperienceDetails(exps); err != nil {
+		return nil, err
+	}
 	return exps, nil
 }
 
 func (db *DB) GetExperienceByID(experienceID int64) (*models.Experience, error) {
-	var e models.Experience
-	var deletedAt string
-	err := db.Conn.QueryRow(`
-		SELECT id, title, company, start_date, end_date, description,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
+	e, err := scanExperience(db.conn().QueryRow(`
+		SELECT`+experienceColumns+`
 		FROM experiences
 		WHERE id = ? AND deleted = 0`, experienceID,
-	).Scan(
-		&e.ID, &e.Title, &e.Company, &e.StartDate, &e.EndDate,
-		&e.Description, &e.Deleted, &e.CreatedAt, &e.UpdatedAt,
-		&deletedAt,
+	))
+	if err != nil {
+		return nil, err
+	}
+	exps := []models.Experience{e}
+	if err := db.loadExperienceDetails(exps); err != nil {
+		return nil, err
+	}
+	return &exps[0], nil
+}
+
+// loadExperienceDetails fills in the highlights, tags and skills of exps
+// with one query each.
+func (db *DB) loadExperienceDetails(exps []models.Experience) error {
+	if len(exps) == 0 {
+		return nil
+	}
+	index := make(map[int64]int, len(exps))
+	for i, e := range exps {
+		index[e.ID] = i
+	}
+
+	rows, err := db.conn().Query(`
+		SELECT experience_id, text
+		FROM experience_highlights
+		ORDER BY experience_id, sort_order, id`,
 	)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var text string
+		if err := rows.Scan(&id, &text); err != nil {
+			rows.Close()
+			return err
+		}
+		if i, ok := index[id]; ok {
+			exps[i].Highlights = append(exps[i].Highlights, text)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
+	tags, err := db.tagsByItem(models.TagKindExperience)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	for i := range exps {
+		exps[i].Tags = tags[exps[i].ID]
+	}
+
+	rows, err = db.conn().Query(`
+		SELECT es.experience_id,` + skillColumns + `
+		FROM experience_skills es
+		JOIN skills s ON s.id = es.skill_id
+		JOIN skill_categories sc ON s.category_id = sc.id
+		WHERE s.deleted = 0
+		ORDER BY s.proficiency DESC, s.name`,
+	)
+	if err != nil {
+		return err
 	}
-	return &e, nil
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		s, err := scanSkill(rowScanner(func(dest ...any) error {
+			return rows.Scan(append([]any{&id}, dest...)...)
+		}))
+		if err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			exps[i].Skills = append(exps[i].Skills, s)
+		}
+	}
+	return rows.Err()
 }
 
-func (db *DB) CreateExperience(title, company, startDate, endDate, description string) (int64, error) {
-	res, err := db.Conn.Exec(`
-		INSERT INTO experiences (title, company, start_date, end_date, description)
-		VALUES (?, ?, ?, ?, ?)`,
-		title, company, startDate, endDate, description,
+// rowScanner adapts a function to the scanner interface, for scanning
+// extra leading columns before handing the rest to a scanX helper.
+type rowScanner func(dest ...any) error
+
+func (f rowScanner) Scan(dest ...any) error { return f(dest...) }
+
+// CreateExperience inserts e with its highlights and tags, linking it
+// to the skills in skillIDs.
+func (db *DB) CreateExperience(e models.Experience, skillIDs []int64) (int64, error) {
+	tx, err := db.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		INSERT INTO experiences
+			(title, company, location, employment_type, start_date, end_date, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		e.Title, e.Company, e.Location, e.EmploymentType,
+		e.StartDate, e.EndDate, e.Description,
 	)
 	if err != nil {
 		return 0, err
 	}
-	return res.LastInsertId()
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := writeExperienceDetails(tx, id, e.Highlights, skillIDs); err != nil {
+		return 0, err
+	}
+	if err := setTags(tx, models.TagKindExperience, id, e.Tags); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
 }
 
-func (db *DB) UpdateExperience(id int64, title, company, startDate, endDate, description string) error {
-	_, err := db.Conn.Exec(`
+// UpdateExperience saves e, replacing its highlights, tags and skill
+// links.
+func (db *DB) UpdateExperience(e models.Experience, skillIDs []int64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
 		UPDATE experiences
-		SET title = ?, company = ?, start_date = ?, end_date = ?,
-		    description = ?, updated_at = CURRENT_TIMESTAMP
+		SET title = ?, company = ?, location = ?, employment_type = ?,
+		    start_date = ?, end_date = ?, description = ?,
+		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND deleted = 0`,
-		title, company, startDate, endDate, description, id,
-	)
-	return err
+		e.Title, e.Company, e.Location, e.EmploymentType,
+		e.StartDate, e.EndDate, e.Description, e.ID,
+	); err != nil {
+		return err
+	}
+	for _, q := range []string{
+		`DELETE FROM experience_highlights WHERE experience_id = ?`,
+		`DELETE FROM experience_skills WHERE experience_id = ?`,
+	} {
+		if _, err := tx.Exec(q, e.ID); err != nil {
+			return err
+		}
+	}
+	if err := writeExperienceDetails(tx, e.ID, e.Highlights, skillIDs); err != nil {
+		return err
+	}
+	if err := setTags(tx, models.TagKindExperience, e.ID, e.Tags); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func writeExperienceDetails(tx *txn, id int64, highlights []string, skillIDs []int64) error {
+	for i, text := range highlights {
+		if _, err := tx.Exec(`
+			INSERT INTO experience_highlights (experience_id, text, sort_order)
+			VALUES (?, ?, ?)`, id, text, i,
+		); err != nil {
+			return err
+		}
+	}
+	for _, skillID := range skillIDs {
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO experience_skills (experience_id, skill_id)
+			VALUES (?, ?)`, id, skillID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (db *DB) SoftDeleteExperience(id int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		UPDATE experiences
 		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`, id,
@@ -536,74 +1272,264 @@ func (db *DB) SoftDeleteExperience(id int64) error {
 	return err
 }
 
+// yearMonthGlob matches a value that starts with a year and month.
+const yearMonthGlob = `'[0-9][0-9][0-9][0-9]-[0-9][0-9]*'`
+
+// normalizeExperienceDates trims full dates stored before dates became
+// year-month values ("2023-06-01" becomes "2023-06"). Other values are
+// left alone and logged, so the admin can fix them by hand.
+func (db *DB) normalizeExperienceDates() error {
+	for _, column := range []string{"start_date", "end_date"} {
+		if _, err := db.conn().Exec(`
+			UPDATE experiences SET ` + column + ` = substr(` + column + `, 1, 7)
+			WHERE length(` + column + `) > 7
+			  AND ` + column + ` GLOB ` + yearMonthGlob,
+		); err != nil {
+			return err
+		}
+	}
+
+	rows, err := db.conn().Query(`
+		SELECT id, start_date, end_date FROM experiences
+		WHERE start_date NOT GLOB ` + yearMonthGlob + `
+		   OR (end_date != '' AND end_date NOT GLOB ` + yearMonthGlob + `)`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var start, end string
+		if err := rows.Scan(&id, &start, &end); err != nil {
+			return err
+		}
+		log.Printf("experience %d: dates %q to %q are not YYYY-MM; left as they are", id, start, end)
+	}
+	return rows.Err()
+}
+
 // ==================== Education ====================
 
+const educationColumns = `
+		id, degree, college, major, minor, start_date, end_date, gpa,
+		in_progress, honors, deleted, COALESCE(updated_at, ''),
+		COALESCE(deleted_at, '')`
+
+func scanEducation(sc scanner) (models.Education, error) {
+	var e models.Education
+	var updatedAt, deletedAt string
+	if err := sc.Scan(
+		&e.ID, &e.Degree, &e.College, &e.Major, &e.Minor, &e.StartDate,
+		&e.EndDate, &e.Gpa, &e.In_progress, &e.Honors, &e.Deleted,
+		&updatedAt, &deletedAt,
+	); err != nil {
+		return e, err
+	}
+	if updatedAt != "" {
+		e.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+	}
+	if deletedAt != "" {
+		e.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return e, nil
+}
+
+// GetAllEducation returns every non-deleted education entry, in-progress
+// first and then most recent first, with its coursework and linked
+// projects (of any visibility).
 func (db *DB) GetAllEducation() ([]models.Education, error) {
-	rows, err := db.Conn.Query(`
-		SELECT id, degree, college, gpa, in_progress
+	rows, err := db.conn().Query(`
+		SELECT` + educationColumns + `
 		FROM education
-		ORDER BY in_progress DESC, degree`,
+		WHERE deleted = 0
+		ORDER BY in_progress DESC, end_date DESC, start_date DESC, degree`,
 	)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
 	var edus []models.Education
 	for rows.Next() {
-		var e models.Education
-		if err := rows.Scan(&e.ID, &e.Degree, &e.College, &e.Gpa, &e.In_progress); err != nil {
+		e, err := scanEducation(rows)
+		if err != nil {
+			rows.Close()
 			return nil, err
 		}
 		edus = append(edus, e)
 	}
-	return edus, nil
-}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-func (db *DB) CreateEducation(degree, college string, gpa float64, inProgress bool) error {
-	ip := 0
-	if inProgress {
-		ip = 1
+	if err := db.loadEducationDetails(edus); err != nil {
+		return nil, err
 	}
-	_, err := db.Conn.Exec(`
-		INSERT INTO education (degree, college, gpa, in_progress)
-		VALUES (?, ?, ?, ?)`,
-		degree, college, gpa, ip,
-	)
-	return err
+	return edus, nil
 }
 
 func (db *DB) GetEducationByID(id int64) (*models.Education, error) {
-	var e models.Education
-	err := db.Conn.QueryRow(`
-		SELECT id, degree, college, gpa, in_progress
+	e, err := scanEducation(db.conn().QueryRow(`
+		SELECT`+educationColumns+`
 		FROM education
-		WHERE id = ?`, id,
-	).Scan(&e.ID, &e.Degree, &e.College, &e.Gpa, &e.In_progress)
+		WHERE id = ? AND deleted = 0`, id,
+	))
 	if err != nil {
 		return nil, err
 	}
-	return &e, nil
+	edus := []models.Education{e}
+	if err := db.loadEducationDetails(edus); err != nil {
+		return nil, err
+	}
+	return &edus[0], nil
 }
 
-func (db *DB) UpdateEducation(
-	id int64, degree, college string, gpa float64, inProgress bool,
-) error {
-	ip := 0
-	if inProgress {
-		ip = 1
+// loadEducationDetails fills in the coursework and projects of edus with
+// one query each.
+func (db *DB) loadEducationDetails(edus []models.Education) error {
+	if len(edus) == 0 {
+		return nil
 	}
-	_, err := db.Conn.Exec(`
-		UPDATE education
-		SET degree = ?, college = ?, gpa = ?, in_progress = ?
-		WHERE id = ?`,
-		degree, college, gpa, ip, id,
+	index := make(map[int64]int, len(edus))
+	for i, e := range edus {
+		index[e.ID] = i
+	}
+
+	rows, err := db.conn().Query(`
+		SELECT education_id, name
+		FROM education_courses
+		ORDER BY education_id, sort_order, id`,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return err
+		}
+		if i, ok := index[id]; ok {
+			edus[i].Coursework = append(edus[i].Coursework, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.conn().Query(`
+		SELECT ep.education_id,` + projectColumns + `
+		FROM education_projects ep
+		JOIN projects p ON p.id = ep.project_id
+		WHERE p.deleted = 0
+		ORDER BY p.created_at DESC`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		p, err := scanProject(rowScanner(func(dest ...any) error {
+			return rows.Scan(append([]any{&id}, dest...)...)
+		}))
+		if err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			edus[i].Projects = append(edus[i].Projects, p)
+		}
+	}
+	return rows.Err()
+}
+
+// CreateEducation inserts e with its coursework, linking it to the
+// projects in projectIDs.
+func (db *DB) CreateEducation(e models.Education, projectIDs []int64) (int64, error) {
+	tx, err := db.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		INSERT INTO education
+			(degree, college, major, minor, start_date, end_date, gpa,
+			 in_progress, honors, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		e.Degree, e.College, e.Major, e.Minor, e.StartDate, e.EndDate,
+		e.Gpa, e.In_progress, e.Honors,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := writeEducationDetails(tx, id, e.Coursework, projectIDs); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// UpdateEducation saves e, replacing its coursework and project links.
+func (db *DB) UpdateEducation(e models.Education, projectIDs []int64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE education
+		SET degree = ?, college = ?, major = ?, minor = ?, start_date = ?,
+		    end_date = ?, gpa = ?, in_progress = ?, honors = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		e.Degree, e.College, e.Major, e.Minor, e.StartDate, e.EndDate,
+		e.Gpa, e.In_progress, e.Honors, e.ID,
+	); err != nil {
+		return err
+	}
+	for _, q := range []string{
+		`DELETE FROM education_courses WHERE education_id = ?`,
+		`DELETE FROM education_projects WHERE education_id = ?`,
+	} {
+		if _, err := tx.Exec(q, e.ID); err != nil {
+			return err
+		}
+	}
+	if err := writeEducationDetails(tx, e.ID, e.Coursework, projectIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func writeEducationDetails(tx *txn, id int64, courses []string, projectIDs []int64) error {
+	for i, name := range courses {
+		if _, err := tx.Exec(`
+			INSERT INTO education_courses (education_id, name, sort_order)
+			VALUES (?, ?, ?)`, id, name, i,
+		); err != nil {
+			return err
+		}
+	}
+	for _, projectID := range projectIDs {
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO education_projects (education_id, project_id)
+			VALUES (?, ?)`, id, projectID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (db *DB) SoftDeleteEducation(id int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		UPDATE education
 		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`, id,
@@ -613,125 +1539,248 @@ func (db *DB) SoftDeleteEducation(id int64) error {
 
 // ==================== Blog Posts ====================
 
-func (db *DB) GetPublishedPosts() ([]models.BlogPost, error) {
-	rows, err := db.Conn.Query(`
-		SELECT id, title, slug, excerpt, content, tags, published,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
-		FROM blog_posts
-		WHERE published = 1 AND deleted = 0
-		ORDER BY created_at DESC`,
-	)
+// postColumns is the column list every post query selects; keep it in
+// step with scanPost. The tags column is only a copy for the search
+// index; tags are read from taggings.
+const postColumns = `
+		b.id, b.title, b.slug, b.excerpt, b.content, b.published,
+		COALESCE(b.publish_at, ''), COALESCE(b.unpublish_at, ''),
+		b.deleted, b.created_at, b.updated_at, COALESCE(b.deleted_at, '')`
+
+// livePost matches posts shown on the public site: published, due and
+// not yet expired. Schedule times are stored in UTC, like
+// datetime('now').
+const livePost = `b.published = 1 AND b.deleted = 0
+		AND (b.publish_at IS NULL OR b.publish_at <= datetime('now'))
+		AND (b.unpublish_at IS NULL OR b.unpublish_at > datetime('now'))`
+
+func scanPost(sc scanner) (models.BlogPost, error) {
+	var p models.BlogPost
+	var publishAt, unpublishAt, deletedAt string
+	if err := sc.Scan(
+		&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Content, &p.Published,
+		&publishAt, &unpublishAt,
+		&p.Deleted, &p.CreatedAt, &p.UpdatedAt, &deletedAt,
+	); err != nil {
+		return p, err
+	}
+	if publishAt != "" {
+		p.PublishAt, _ = time.Parse("2006-01-02 15:04:05", publishAt)
+	}
+	if unpublishAt != "" {
+		p.UnpublishAt, _ = time.Parse("2006-01-02 15:04:05", unpublishAt)
+	}
+	if deletedAt != "" {
+		p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return p, nil
+}
+
+// queryPosts runs a post query and fills in each post's tags.
+func (db *DB) queryPosts(query string, args ...any) ([]models.BlogPost, error) {
+	rows, err := db.conn().Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
 	var posts []models.BlogPost
 	for rows.Next() {
-		var p models.BlogPost
-		var deletedAt string
-		if err := rows.Scan(
-			&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Content, &p.Tags,
-			&p.Published, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-			&deletedAt,
-		); err != nil {
+		p, err := scanPost(rows)
+		if err != nil {
+			rows.Close()
 			return nil, err
 		}
-		if deletedAt != "" {
-			p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
 		posts = append(posts, p)
 	}
-	return posts, nil
-}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-func (db *DB) GetAllPosts() ([]models.BlogPost, error) {
-	rows, err := db.Conn.Query(`
-		SELECT id, title, slug, excerpt, content, tags, published,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
-		FROM blog_posts
-		WHERE deleted = 0
-		ORDER BY created_at DESC`,
-	)
+	tags, err := db.tagsByItem(models.TagKindPost)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var posts []models.BlogPost
-	for rows.Next() {
-		var p models.BlogPost
-		var deletedAt string
-		if err := rows.Scan(
-			&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Content, &p.Tags,
-			&p.Published, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-			&deletedAt,
-		); err != nil {
-			return nil, err
-		}
-		if deletedAt != "" {
-			p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
-		}
-		posts = append(posts, p)
+	for i := range posts {
+		posts[i].Tags = tags[posts[i].ID]
 	}
 	return posts, nil
 }
 
-func (db *DB) GetPostBySlug(slug string) (*models.BlogPost, error) {
-	var p models.BlogPost
-	var deletedAt string
-	err := db.Conn.QueryRow(`
-		SELECT id, title, slug, excerpt, content, tags, published,
-		       deleted, created_at, updated_at, COALESCE(deleted_at, '')
-		FROM blog_posts
-		WHERE slug = ? AND published = 1 AND deleted = 0`, slug,
-	).Scan(
-		&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Content, &p.Tags,
-		&p.Published, &p.Deleted, &p.CreatedAt, &p.UpdatedAt,
-		&deletedAt,
+// postDate orders posts by the date they are shown with (see
+// models.BlogPost.Date).
+const postDate = `COALESCE(b.publish_at, b.created_at)`
+
+// GetPublishedPosts returns the posts live on the public site, newest
+// first.
+func (db *DB) GetPublishedPosts() ([]models.BlogPost, error) {
+	return db.queryPosts(`
+		SELECT` + postColumns + `
+		FROM blog_posts b
+		WHERE ` + livePost + `
+		ORDER BY ` + postDate + ` DESC`,
 	)
+}
+
+// GetAllPosts returns every non-deleted post, whatever its state.
+func (db *DB) GetAllPosts() ([]models.BlogPost, error) {
+	return db.queryPosts(`
+		SELECT` + postColumns + `
+		FROM blog_posts b
+		WHERE b.deleted = 0
+		ORDER BY ` + postDate + ` DESC`,
+	)
+}
+
+// GetPostBySlug looks up a post live on the public site.
+func (db *DB) GetPostBySlug(slug string) (*models.BlogPost, error) {
+	return db.getPost(`b.slug = ? AND `+livePost, slug)
+}
+
+// GetPostByID looks up a non-deleted post, whatever its state.
+func (db *DB) GetPostByID(id int64) (*models.BlogPost, error) {
+	return db.getPost(`b.id = ? AND b.deleted = 0`, id)
+}
+
+func (db *DB) getPost(where string, args ...any) (*models.BlogPost, error) {
+	p, err := scanPost(db.conn().QueryRow(`
+		SELECT`+postColumns+`
+		FROM blog_posts b
+		WHERE `+where, args...,
+	))
 	if err != nil {
 		return nil, err
 	}
-	if deletedAt != "" {
-		p.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	if p.Tags, err = db.GetTags(models.TagKindPost, p.ID); err != nil {
+		return nil, err
 	}
 	return &p, nil
 }
 
+// CreateBlogPost inserts a post. tags is a comma-separated list.
 func (db *DB) CreateBlogPost(title, slug, excerpt, content, tags string, published bool) (int64, error) {
 	pub := 0
 	if published {
 		pub = 1
 	}
-	res, err := db.Conn.Exec(`
+	names := models.SplitTags(tags)
+
+	tx, err := db.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
 		INSERT INTO blog_posts (title, slug, excerpt, content, tags, published)
 		VALUES (?, ?, ?, ?, ?, ?)`,
-		title, slug, excerpt, content, tags, pub,
+		title, slug, excerpt, content, strings.Join(names, ", "), pub,
 	)
 	if err != nil {
 		return 0, err
 	}
-	return res.LastInsertId()
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := setTags(tx, models.TagKindPost, id, names); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
 }
 
+// UpdateBlogPost saves a post. tags is a comma-separated list.
 func (db *DB) UpdateBlogPost(id int64, title, slug, excerpt, content, tags string, published bool) error {
 	pub := 0
 	if published {
 		pub = 1
 	}
-	_, err := db.Conn.Exec(`
+	names := models.SplitTags(tags)
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
 		UPDATE blog_posts
 		SET title = ?, slug = ?, excerpt = ?, content = ?, tags = ?,
 		    published = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND deleted = 0`,
-		title, slug, excerpt, content, tags, pub, id,
+		title, slug, excerpt, content, strings.Join(names, ", "), pub, id,
+	); err != nil {
+		return err
+	}
+	if err := setTags(tx, models.TagKindPost, id, names); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SchedulePost sets whether a post is published and the window it is
+// live in. A zero publishAt publishes it straight away and a zero
+// unpublishAt keeps it up for good.
+func (db *DB) SchedulePost(id int64, published bool, publishAt, unpublishAt time.Time) error {
+	pub := 0
+	if published {
+		pub = 1
+	}
+	_, err := db.conn().Exec(`
+		UPDATE blog_posts
+		SET published = ?, publish_at = ?, unpublish_at = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		pub, nullTime(publishAt), nullTime(unpublishAt), id,
 	)
 	return err
 }
 
+// nullTime stores t in UTC the way datetime('now') writes it, or NULL
+// when t is zero.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// ExpirePosts unpublishes posts whose unpublish time has passed and
+// returns how many it changed.
+func (db *DB) ExpirePosts() (int64, error) {
+	res, err := db.conn().Exec(`
+		UPDATE blog_posts
+		SET published = 0, updated_at = CURRENT_TIMESTAMP
+		WHERE published = 1 AND deleted = 0
+		  AND unpublish_at IS NOT NULL AND unpublish_at <= datetime('now')`,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// NextPostChange returns the next time a published post goes live or
+// expires. ok is false when nothing is scheduled.
+func (db *DB) NextPostChange() (next time.Time, ok bool, err error) {
+	var at string
+	err = db.conn().QueryRow(`
+		SELECT COALESCE(MIN(t), '') FROM (
+			SELECT publish_at AS t FROM blog_posts
+			WHERE published = 1 AND deleted = 0 AND publish_at > datetime('now')
+			UNION ALL
+			SELECT unpublish_at FROM blog_posts
+			WHERE published = 1 AND deleted = 0 AND unpublish_at > datetime('now')
+		)`,
+	).Scan(&at)
+	if err != nil || at == "" {
+		return time.Time{}, false, err
+	}
+	next, err = time.Parse("2006-01-02 15:04:05", at)
+	return next, err == nil, err
+}
+
 func (db *DB) SoftDeleteBlogPost(id int64) error {
-	_, err := db.Conn.Exec(`
+	_, err := db.conn().Exec(`
 		UPDATE blog_posts
 		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`, id,
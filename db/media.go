@@ -0,0 +1,102 @@
+package db
+
+import "github.com/DYankee/resume2/models"
+
+// ==================== Project Media ====================
+
+func (db *DB) GetMediaForProject(projectID int64) ([]models.ProjectMedia, error) {
+	rows, err := db.conn().Query(`
+		SELECT id, project_id, kind, url, caption, alt_text, sort_order
+		FROM project_media
+		WHERE project_id = ?
+		ORDER BY sort_order, id`, projectID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var media []models.ProjectMedia
+	for rows.Next() {
+		var m models.ProjectMedia
+		if err := rows.Scan(
+			&m.ID, &m.ProjectID, &m.Kind, &m.URL, &m.Caption, &m.AltText,
+			&m.SortOrder,
+		); err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+	return media, rows.Err()
+}
+
+// AddProjectMedia appends a media item to the end of a project's gallery.
+func (db *DB) AddProjectMedia(projectID int64, kind, url, caption, altText string) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO project_media (project_id, kind, url, caption, alt_text, sort_order)
+		VALUES (?, ?, ?, ?, ?, (
+			SELECT COALESCE(MAX(sort_order), 0) + 1
+			FROM project_media WHERE project_id = ?
+		))`,
+		projectID, kind, url, caption, altText, projectID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdateProjectMedia(projectID, id int64, caption, altText string) error {
+	_, err := db.conn().Exec(`
+		UPDATE project_media SET caption = ?, alt_text = ?
+		WHERE id = ? AND project_id = ?`,
+		caption, altText, id, projectID,
+	)
+	return err
+}
+
+func (db *DB) DeleteProjectMedia(projectID, id int64) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM project_media WHERE id = ? AND project_id = ?`,
+		id, projectID,
+	)
+	return err
+}
+
+// MoveProjectMedia swaps a media item with its neighbour: up when delta
+// is negative, down otherwise. Moving past either end is a no-op.
+func (db *DB) MoveProjectMedia(projectID, id int64, delta int) error {
+	media, err := db.GetMediaForProject(projectID)
+	if err != nil {
+		return err
+	}
+	for i, m := range media {
+		if m.ID != id {
+			continue
+		}
+		j := i + 1
+		if delta < 0 {
+			j = i - 1
+		}
+		if j < 0 || j >= len(media) {
+			return nil
+		}
+		media[i], media[j] = media[j], media[i]
+		break
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i, m := range media {
+		if _, err := tx.Exec(
+			`UPDATE project_media SET sort_order = ? WHERE id = ?`,
+			i+1, m.ID,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Preview Links ====================
+
+// PreviewSecret returns the key preview links are signed with, creating
+// it on first use.
+func (db *DB) PreviewSecret() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	// Keeps the first key if two requests race to create it.
+	if _, err := db.conn().Exec(
+		`INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)`,
+		SettingPreviewSecret, hex.EncodeToString(key),
+	); err != nil {
+		return nil, err
+	}
+	value, err := db.GetSetting(SettingPreviewSecret)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(value)
+}
+
+// previewLinkColumns is the column list preview link queries select; keep
+// it in step with scanPreviewLink. The title comes from the linked post
+// or project, so queries join them as b and p.
+const previewLinkColumns = `
+		l.id, l.kind, l.item_id, COALESCE(b.title, p.title, ''),
+		l.expires_at, l.created_at`
+
+const previewLinkJoins = `
+		FROM preview_links l
+		LEFT JOIN blog_posts b ON l.kind = 'post' AND b.id = l.item_id
+		LEFT JOIN projects p ON l.kind = 'project' AND p.id = l.item_id`
+
+func scanPreviewLink(sc scanner) (models.PreviewLink, error) {
+	var l models.PreviewLink
+	err := sc.Scan(
+		&l.ID, &l.Kind, &l.ItemID, &l.Title, &l.ExpiresAt, &l.CreatedAt,
+	)
+	return l, err
+}
+
+// CreatePreviewLink stores a link to a post or project valid until
+// expires, clearing out links that have already expired.
+func (db *DB) CreatePreviewLink(kind string, itemID int64, expires time.Time) (*models.PreviewLink, error) {
+	if _, err := db.conn().Exec(
+		`DELETE FROM preview_links WHERE expires_at <= datetime('now')`,
+	); err != nil {
+		return nil, err
+	}
+	res, err := db.conn().Exec(`
+		INSERT INTO preview_links (kind, item_id, expires_at)
+		VALUES (?, ?, ?)`,
+		kind, itemID, nullTime(expires),
+	)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return db.GetPreviewLink(id)
+}
+
+// GetPreviewLink looks up a link that has not expired or been revoked.
+func (db *DB) GetPreviewLink(id int64) (*models.PreviewLink, error) {
+	l, err := scanPreviewLink(db.conn().QueryRow(`
+		SELECT`+previewLinkColumns+previewLinkJoins+`
+		WHERE l.id = ? AND l.expires_at > datetime('now')`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetPreviewLinks returns the outstanding links, soonest to expire
+// first.
+func (db *DB) GetPreviewLinks() ([]models.PreviewLink, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + previewLinkColumns + previewLinkJoins + `
+		WHERE l.expires_at > datetime('now')
+		ORDER BY l.expires_at, l.id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []models.PreviewLink
+	for rows.Next() {
+		l, err := scanPreviewLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// DeletePreviewLink revokes a link. IDs are never reused, so its URL
+// stops working for good.
+func (db *DB) DeletePreviewLink(id int64) error {
+	_, err := db.conn().Exec(`DELETE FROM preview_links WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,59 @@
+package db
+
+import "github.com/DYankee/resume2/models"
+
+// ==================== Profile ====================
+
+// ensureProfile creates the single profile row, pre-filled with the
+// details the resume used before the profile was editable.
+func (db *DB) ensureProfile() error {
+	_, err := db.conn().Exec(`
+		INSERT OR IGNORE INTO profile (
+			id, name, headline, email, linkedin_url, github_url, summary
+		) VALUES (1, ?, ?, ?, ?, ?, ?)`,
+		"Zachary Geary",
+		"Software Developer",
+		"zpgeary@gmail.com",
+		"https://www.linkedin.com/in/zacharygeary",
+		"https://github.com/DYankee",
+		"I'm a highly motivated college student looking to gain experience "+
+			"in the professional world. I have experience developing small "+
+			"websites and programs, working with other developers in an agile "+
+			"like environment and interfacing with customers to ensure product "+
+			"satisfaction. Currently open to work opportunities or internships."+
+			"\n\n"+
+			"I currently attend SUNY Polytechnic full time pursuing a CS degree. "+
+			"In my free time I enjoy playing guitar, making games, coding tools "+
+			"for myself, and going hiking.",
+	)
+	return err
+}
+
+func (db *DB) GetProfile() (*models.Profile, error) {
+	var p models.Profile
+	err := db.conn().QueryRow(`
+		SELECT name, headline, email, phone, location,
+		       linkedin_url, github_url, website_url, summary, updated_at
+		FROM profile WHERE id = 1`,
+	).Scan(
+		&p.Name, &p.Headline, &p.Email, &p.Phone, &p.Location,
+		&p.LinkedInURL, &p.GitHubURL, &p.WebsiteURL, &p.Summary, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (db *DB) UpdateProfile(p models.Profile) error {
+	_, err := db.conn().Exec(`
+		UPDATE profile SET
+			name = ?, headline = ?, email = ?, phone = ?, location = ?,
+			linkedin_url = ?, github_url = ?, website_url = ?, summary = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = 1`,
+		p.Name, p.Headline, p.Email, p.Phone, p.Location,
+		p.LinkedInURL, p.GitHubURL, p.WebsiteURL, p.Summary,
+	)
+	return err
+}
@@ -0,0 +1,74 @@
+package db
+
+import "github.com/DYankee/resume2/models"
+
+// ==================== Resume Projects ====================
+
+// GetResumeProjects returns the projects chosen for the resume in their
+// chosen order, whatever their visibility.
+func (db *DB) GetResumeProjects() ([]models.Project, error) {
+	return db.queryProjects(`
+		SELECT` + projectColumns + `
+		FROM resume_projects rp
+		JOIN projects p ON p.id = rp.project_id
+		WHERE p.deleted = 0
+		ORDER BY rp.sort_order, p.id`,
+	)
+}
+
+// AddResumeProject appends a project to the end of the resume's list.
+// Adding a project that is already listed is a no-op.
+func (db *DB) AddResumeProject(projectID int64) error {
+	_, err := db.conn().Exec(`
+		INSERT OR IGNORE INTO resume_projects (project_id, sort_order)
+		VALUES (?, (
+			SELECT COALESCE(MAX(sort_order), 0) + 1 FROM resume_projects
+		))`, projectID,
+	)
+	return err
+}
+
+func (db *DB) RemoveResumeProject(projectID int64) error {
+	_, err := db.conn().Exec(
+		`DELETE FROM resume_projects WHERE project_id = ?`, projectID,
+	)
+	return err
+}
+
+// MoveResumeProject swaps a project with its neighbour: up when delta is
+// negative, down otherwise. Moving past either end is a no-op.
+func (db *DB) MoveResumeProject(projectID int64, delta int) error {
+	projects, err := db.GetResumeProjects()
+	if err != nil {
+		return err
+	}
+	for i, p := range projects {
+		if p.ID != projectID {
+			continue
+		}
+		j := i + 1
+		if delta < 0 {
+			j = i - 1
+		}
+		if j < 0 || j >= len(projects) {
+			return nil
+		}
+		projects[i], projects[j] = projects[j], projects[i]
+		break
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i, p := range projects {
+		if _, err := tx.Exec(
+			`UPDATE resume_projects SET sort_order = ? WHERE project_id = ?`,
+			i+1, p.ID,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
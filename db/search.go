@@ -0,0 +1,235 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Full-text Search ====================
+//
+// Each searchable table has an external-content FTS5 index kept in sync
+// by triggers. Visibility, publishing and soft deletes are applied when
+// querying, by joining back to the source table. FTS5 needs the
+// sqlite_fts5 build tag (see the makefile).
+
+// ftsIndex describes one FTS5 index over a source table.
+type ftsIndex struct {
+	table   string
+	columns []string
+}
+
+var ftsIndexes = []ftsIndex{
+	{"projects", []string{"title", "description", "long_desc"}},
+	{"blog_posts", []string{"title", "excerpt", "content", "tags"}},
+	{"skills", []string{"name", "description"}},
+	{"experiences", []string{"title", "company", "description"}},
+}
+
+// migrateSearch creates the FTS5 tables and triggers, then rebuilds each
+// index from its source table so rows written before the index existed
+// are searchable too. Without FTS5 it turns search off and drops the
+// triggers, which would otherwise fail every write to an indexed table;
+// the next FTS5 build recreates them and rebuilds the indexes.
+func (db *DB) migrateSearch() error {
+	var fts5 bool
+	if err := db.conn().QueryRow(
+		`SELECT sqlite_compileoption_used('ENABLE_FTS5')`,
+	).Scan(&fts5); err != nil {
+		return err
+	}
+	if !fts5 {
+		log.Print("search disabled: SQLite was built without FTS5 " +
+			"(build with -tags sqlite_fts5)")
+		for _, idx := range ftsIndexes {
+			for _, suffix := range []string{"_ai", "_ad", "_au"} {
+				if _, err := db.conn().Exec(
+					`DROP TRIGGER IF EXISTS ` + idx.table + `_fts` + suffix,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	db.SearchEnabled = true
+
+	for _, idx := range ftsIndexes {
+		fts := idx.table + "_fts"
+		cols := strings.Join(idx.columns, ", ")
+		newCols := "new." + strings.Join(idx.columns, ", new.")
+		oldCols := "old." + strings.Join(idx.columns, ", old.")
+
+		queries := []string{
+			fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(
+				%s, content='%s', content_rowid='id',
+				tokenize='porter unicode61'
+			)`, fts, cols, idx.table),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_ai
+				AFTER INSERT ON %[2]s BEGIN
+				INSERT INTO %[1]s(rowid, %[3]s) VALUES (new.id, %[4]s);
+			END`, fts, idx.table, cols, newCols),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_ad
+				AFTER DELETE ON %[2]s BEGIN
+				INSERT INTO %[1]s(%[1]s, rowid, %[3]s)
+				VALUES ('delete', old.id, %[4]s);
+			END`, fts, idx.table, cols, oldCols),
+			fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_au
+				AFTER UPDATE ON %[2]s BEGIN
+				INSERT INTO %[1]s(%[1]s, rowid, %[3]s)
+				VALUES ('delete', old.id, %[4]s);
+				INSERT INTO %[1]s(rowid, %[3]s) VALUES (new.id, %[5]s);
+			END`, fts, idx.table, cols, oldCols, newCols),
+			fmt.Sprintf(`INSERT INTO %[1]s(%[1]s) VALUES ('rebuild')`, fts),
+		}
+		for _, q := range queries {
+			if _, err := db.conn().Exec(q); err != nil {
+				return fmt.Errorf("%w\n%s", err, q)
+			}
+		}
+	}
+	return nil
+}
+
+// Snippet highlight markers; control characters never appear in content.
+const (
+	markStart = "\x02"
+	markEnd   = "\x03"
+)
+
+// ftsQuery turns free text into an FTS5 query that requires every word
+// as a prefix match. Quoting each term keeps FTS5 operators and
+// punctuation typed by visitors from being parsed as syntax.
+func ftsQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + w + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// splitSnippet breaks an FTS5 snippet at the highlight markers.
+func splitSnippet(s string) []models.SnippetPart {
+	var parts []models.SnippetPart
+	for s != "" {
+		start := strings.Index(s, markStart)
+		if start < 0 {
+			parts = append(parts, models.SnippetPart{Text: s})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, models.SnippetPart{Text: s[:start]})
+		}
+		s = s[start+len(markStart):]
+		end := strings.Index(s, markEnd)
+		if end < 0 {
+			end = len(s)
+		}
+		parts = append(parts, models.SnippetPart{Text: s[:end], Match: true})
+		s = strings.TrimPrefix(s[end:], markEnd)
+	}
+	return parts
+}
+
+// Search runs q against every index and returns up to limit hits per
+// content type. Only content visible on the public site is returned.
+func (db *DB) Search(q string, limit int) (models.SearchResults, error) {
+	res := models.SearchResults{Query: q}
+	match := ftsQuery(q)
+	if match == "" || !db.SearchEnabled {
+		return res, nil
+	}
+	// A query naming a listed skill by one of its aliases also finds the
+	// skill's own name, so "golang" finds posts about Go. Hidden skills
+	// are left out, so a search can't reveal their names.
+	skills, err := db.GetListedSkills()
+	if err != nil {
+		return res, err
+	}
+	if err := db.LoadSkillAliases(skills); err != nil {
+		return res, err
+	}
+	if i := models.FindSkill(skills, q); i >= 0 &&
+		models.NormalizeSkillName(skills[i].Name) != models.NormalizeSkillName(q) {
+		if name := ftsQuery(skills[i].Name); name != "" {
+			match = "(" + match + ") OR (" + name + ")"
+		}
+	}
+
+	res.Projects, err = db.searchIndex(models.SearchProject, `
+		SELECT p.title, '/projects/' || p.slug,
+		       snippet(projects_fts, -1, ?, ?, '…', 16),
+		       bm25(projects_fts)
+		FROM projects_fts
+		JOIN projects p ON p.id = projects_fts.rowid
+		WHERE projects_fts MATCH ? AND p.deleted = 0 AND `+listedProject+`
+		ORDER BY bm25(projects_fts)
+		LIMIT ?`, match, limit,
+	)
+	if err != nil {
+		return res, err
+	}
+	res.Posts, err = db.searchIndex(models.SearchPost, `
+		SELECT b.title, '/blog/' || b.slug,
+		       snippet(blog_posts_fts, -1, ?, ?, '…', 16),
+		       bm25(blog_posts_fts)
+		FROM blog_posts_fts
+		JOIN blog_posts b ON b.id = blog_posts_fts.rowid
+		WHERE blog_posts_fts MATCH ? AND `+livePost+`
+		ORDER BY bm25(blog_posts_fts)
+		LIMIT ?`, match, limit,
+	)
+	if err != nil {
+		return res, err
+	}
+	res.Skills, err = db.searchIndex(models.SearchSkill, `
+		SELECT s.name, '/projects?skill=' || s.id,
+		       snippet(skills_fts, -1, ?, ?, '…', 16),
+		       bm25(skills_fts)
+		FROM skills_fts
+		JOIN skills s ON s.id = skills_fts.rowid
+		WHERE skills_fts MATCH ? AND s.deleted = 0 AND `+listedSkill+`
+		ORDER BY bm25(skills_fts)
+		LIMIT ?`, match, limit,
+	)
+	if err != nil {
+		return res, err
+	}
+	res.Experiences, err = db.searchIndex(models.SearchExperience, `
+		SELECT e.title || ' — ' || e.company, '/resume',
+		       snippet(experiences_fts, -1, ?, ?, '…', 16),
+		       bm25(experiences_fts)
+		FROM experiences_fts
+		JOIN experiences e ON e.id = experiences_fts.rowid
+		WHERE experiences_fts MATCH ? AND e.deleted = 0
+		ORDER BY bm25(experiences_fts)
+		LIMIT ?`, match, limit,
+	)
+	return res, err
+}
+
+func (db *DB) searchIndex(kind, query, match string, limit int) ([]models.SearchHit, error) {
+	rows, err := db.conn().Query(query, markStart, markEnd, match, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []models.SearchHit
+	for rows.Next() {
+		h := models.SearchHit{Kind: kind}
+		var snippet string
+		if err := rows.Scan(&h.Title, &h.URL, &snippet, &h.Rank); err != nil {
+			return nil, err
+		}
+		h.Snippet = splitSnippet(snippet)
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Section Order ====================
+
+// GetSectionOrder returns the sections shown on surface, in order. A
+// surface the admin never arranged uses models.DefaultSections.
+func (db *DB) GetSectionOrder(surface string) ([]string, error) {
+	value, err := db.GetSetting(SettingSectionsPrefix + surface)
+	if err != nil || value == "" {
+		return models.DefaultSections(surface), err
+	}
+	return strings.Split(value, ","), nil
+}
+
+func (db *DB) SetSectionOrder(surface string, sections []string) error {
+	return db.SetSetting(SettingSectionsPrefix+surface, strings.Join(sections, ","))
+}
+
+// ==================== Custom Sections ====================
+
+const customSectionColumns = `
+		id, title, body, deleted, created_at, updated_at,
+		COALESCE(deleted_at, '')`
+
+func scanCustomSection(sc scanner) (models.CustomSection, error) {
+	var s models.CustomSection
+	var deletedAt string
+	if err := sc.Scan(
+		&s.ID, &s.Title, &s.Body, &s.Deleted, &s.CreatedAt, &s.UpdatedAt,
+		&deletedAt,
+	); err != nil {
+		return s, err
+	}
+	if deletedAt != "" {
+		s.DeletedAt, _ = time.Parse("2006-01-02 15:04:05", deletedAt)
+	}
+	return s, nil
+}
+
+// GetAllCustomSections returns every non-deleted custom section, oldest
+// first.
+func (db *DB) GetAllCustomSections() ([]models.CustomSection, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + customSectionColumns + `
+		FROM custom_sections
+		WHERE deleted = 0
+		ORDER BY id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []models.CustomSection
+	for rows.Next() {
+		s, err := scanCustomSection(rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+	return sections, rows.Err()
+}
+
+func (db *DB) GetCustomSectionByID(id int64) (*models.CustomSection, error) {
+	s, err := scanCustomSection(db.conn().QueryRow(`
+		SELECT`+customSectionColumns+`
+		FROM custom_sections
+		WHERE id = ? AND deleted = 0`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (db *DB) CreateCustomSection(s models.CustomSection) (int64, error) {
+	res, err := db.conn().Exec(`
+		INSERT INTO custom_sections (title, body)
+		VALUES (?, ?)`,
+		s.Title, s.Body,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (db *DB) UpdateCustomSection(s models.CustomSection) error {
+	_, err := db.conn().Exec(`
+		UPDATE custom_sections
+		SET title = ?, body = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted = 0`,
+		s.Title, s.Body, s.ID,
+	)
+	return err
+}
+
+// SoftDeleteCustomSection deletes the section. Surfaces that place it
+// keep its key, which renders nothing.
+func (db *DB) SoftDeleteCustomSection(id int64) error {
+	_, err := db.conn().Exec(`
+		UPDATE custom_sections
+		SET deleted = 1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, id,
+	)
+	return err
+}
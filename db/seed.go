@@ -1,10 +1,14 @@
 package db
 
-import "log"
+import (
+	"log"
+
+	"github.com/DYankee/resume2/models"
+)
 
 func (db *DB) Seed() {
 	var count int
-	db.Conn.QueryRow("SELECT COUNT(*) FROM skills").Scan(&count)
+	db.conn().QueryRow("SELECT COUNT(*) FROM skills").Scan(&count)
 	if count > 0 {
 		return
 	}
@@ -42,51 +46,78 @@ func (db *DB) Seed() {
 		{"Git", "Software", "Version control software for managing and maintaining code bases. Git is in my opinion the gold standard for version control. While ", "", 65},
 	}
 
-	skillIDs := make(map[string]int64)
 	for _, s := range skills {
-		id, _ := db.CreateSkill(s.name, catIDs[s.category], s.description, s.icon, s.proficiency)
-		skillIDs[s.name] = id
+		db.CreateSkill(s.name, catIDs[s.category], s.description, s.icon, s.proficiency, models.VisibilityPublic)
+	}
+
+	// Other names the skills go by
+	aliases := map[string][]string{
+		"GoLang":       {"Go"},
+		"JavaScript":   {"JS"},
+		"Tailwind CSS": {"Tailwind"},
+		"SQLite":       {"SQLite3"},
+	}
+	for name, names := range aliases {
+		if skill, _ := db.ResolveSkillName(name); skill != nil {
+			db.SetSkillAliases(skill.ID, skill.Name, names)
+		}
+	}
+	linkSkills := func(projectID int64, names ...string) {
+		for _, name := range names {
+			if skill, _ := db.ResolveSkillName(name); skill != nil {
+				db.AddSkillToProject(skill.ID, projectID)
+			}
+		}
 	}
 
 	// Projects
 	p1ID, _ := db.CreateProject(
+		"",
 		"Portfolio Website",
 		"My personal portfolio built with the GOTH stack.",
 		"A simple website I built to host my blog and show off what im working on. Built using the GOTH stack which is Golang, Templ, Tailwind, and HTMX.",
 		"", "https://github.com/you/portfolio", "https://example.com",
+		models.VisibilityFeatured,
 	)
 	p2ID, _ := db.CreateProject(
+		"",
 		"RipR",
 		"A TUI program to help with recording and splitting records with audacity.",
 		"A basic TUI that uses audacity along with the musicBrains API to find the length of each track, account for the variance between the length of the original and user recording. It then exports each track to the desired folder. UI built using the bubbleTea go framework",
 		"", "https://github.com/you/taskcli", "",
+		models.VisibilityPublic,
 	)
 
-	// Link skills to projects via skill_uses
-	for _, skillName := range []string{"GoLang", "HTMX", "Tailwind CSS", "Echo", "SQLite", "Docker"} {
-		db.AddSkillToProject(skillIDs[skillName], p1ID)
-	}
-	for _, skillName := range []string{"GoLang", "BubbleTea"} {
-		db.AddSkillToProject(skillIDs[skillName], p2ID)
-	}
+	// Link skills to projects via skill_uses, by name or alias
+	linkSkills(p1ID, "Go", "HTMX", "Tailwind", "Echo", "SQLite", "Docker")
+	linkSkills(p2ID, "Go", "BubbleTea")
 
 	//Education
-	db.CreateEducation("BS Computer Science", "Suny Polytechnic", 3.29, true)
-	db.CreateEducation("AAS Computer Information Systems", "Suny Onondaga Community College", 3.83, false)
+	db.CreateEducation(models.Education{
+		Degree: "BS Computer Science", College: "Suny Polytechnic",
+		Gpa: 3.29, In_progress: true,
+	}, nil)
+	db.CreateEducation(models.Education{
+		Degree: "AAS Computer Information Systems", College: "Suny Onondaga Community College",
+		Gpa: 3.83,
+	}, nil)
 
 	// Work Experience
-	db.CreateExperience(
-		"Fulfillment Center Warehouse Associate", "Amazon",
-		"2023-06", "", "Performed audits on employee performance and provided corrective coaching when necessary. Assessed damaged products and determined if and where they should be disposed of.",
-	)
-	db.CreateExperience(
-		"Switcher", "Fed-Ex Ground",
-		"2021-09", "2023-06", "Used an electric switcher to move trailers around the yard and out them in their designated spaces",
-	)
-	db.CreateExperience(
-		"Assistant Director", "Chick-Fil-A",
-		"2016-11", "2019-09", "Managed front of house staff in a fast paced restaurant environment. Received and handled guest complaints in person and over the phone.",
-	)
+	db.CreateExperience(models.Experience{
+		Title: "Fulfillment Center Warehouse Associate", Company: "Amazon",
+		StartDate:   "2023-06",
+		Description: "Performed audits on employee performance and provided corrective coaching when necessary. Assessed damaged products and determined if and where they should be disposed of.",
+	}, nil)
+	db.CreateExperience(models.Experience{
+		Title: "Switcher", Company: "Fed-Ex Ground",
+		StartDate: "2021-09", EndDate: "2023-06",
+		Description: "Used an electric switcher to move trailers around the yard and out them in their designated spaces",
+	}, nil)
+	db.CreateExperience(models.Experience{
+		Title: "Assistant Director", Company: "Chick-Fil-A",
+		StartDate: "2016-11", EndDate: "2019-09",
+		Description: "Managed front of house staff in a fast paced restaurant environment. Received and handled guest complaints in person and over the phone.",
+	}, nil)
 
 	// Blog posts
 	db.CreateBlogPost(
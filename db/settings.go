@@ -0,0 +1,42 @@
+package db
+
+import "database/sql"
+
+// ==================== Settings ====================
+
+// Setting keys. Values are stored as plain strings.
+const (
+	SettingResumeTheme = "resume_theme"
+	// Followed by a theme name, e.g. "resume_font_classic".
+	SettingResumeFontPrefix = "resume_font_"
+	// Followed by a surface, e.g. "sections_pdf". The value is the
+	// comma-separated section order.
+	SettingSectionsPrefix = "sections_"
+	// One of models.SkillOrders.
+	SettingResumeSkillOrder = "resume_skill_order"
+	// Hex key signing preview links, generated on first use.
+	SettingPreviewSecret = "preview_secret"
+)
+
+// GetSetting returns the stored value for key, or "" if it was never set.
+func (db *DB) GetSetting(key string) (string, error) {
+	var value string
+	err := db.conn().QueryRow(
+		"SELECT value FROM settings WHERE key = ?", key,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+func (db *DB) SetSetting(key, value string) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO settings (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			value = excluded.value,
+			updated_at = CURRENT_TIMESTAMP`,
+		key, value,
+	)
+	return err
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Skill Aliases ====================
+
+// LoadSkillAliases fills in the Aliases of each skill, in the order they
+// were entered.
+func (db *DB) LoadSkillAliases(skills []models.Skill) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	index := make(map[int64]int, len(skills))
+	for i := range skills {
+		skills[i].Aliases = nil
+		index[skills[i].ID] = i
+	}
+
+	rows, err := db.conn().Query(`SELECT skill_id, alias FROM skill_aliases ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var skillID int64
+		var alias string
+		if err := rows.Scan(&skillID, &alias); err != nil {
+			return err
+		}
+		if i, ok := index[skillID]; ok {
+			skills[i].Aliases = append(skills[i].Aliases, alias)
+		}
+	}
+	return rows.Err()
+}
+
+// GetSkillAliases returns the aliases of one skill.
+func (db *DB) GetSkillAliases(skillID int64) ([]string, error) {
+	rows, err := db.conn().Query(`
+		SELECT alias FROM skill_aliases WHERE skill_id = ? ORDER BY id`, skillID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []string
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
+
+// SetSkillAliases replaces the aliases of a skill. Aliases that normalize
+// to the skill's name or to an earlier alias are dropped.
+func (db *DB) SetSkillAliases(skillID int64, name string, aliases []string) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM skill_aliases WHERE skill_id = ?`, skillID); err != nil {
+		return err
+	}
+	seen := map[string]bool{models.NormalizeSkillName(name): true}
+	for _, alias := range aliases {
+		key := models.NormalizeSkillName(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if _, err := tx.Exec(`
+			INSERT INTO skill_aliases (skill_id, alias, normalized)
+			VALUES (?, ?, ?)`, skillID, alias, key,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetAllSkillsWithAliases returns every non-deleted skill with its
+// aliases loaded, ready for models.FindSkill.
+func (db *DB) GetAllSkillsWithAliases() ([]models.Skill, error) {
+	skills, err := db.GetAllSkills()
+	if err != nil {
+		return nil, err
+	}
+	return skills, db.LoadSkillAliases(skills)
+}
+
+// ResolveSkillName finds the non-deleted skill a name or alias refers
+// to. It returns nil if none does.
+func (db *DB) ResolveSkillName(name string) (*models.Skill, error) {
+	skills, err := db.GetAllSkillsWithAliases()
+	if err != nil {
+		return nil, err
+	}
+	i := models.FindSkill(skills, name)
+	if i < 0 {
+		return nil, nil
+	}
+	return &skills[i], nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Skill Metrics ====================
+
+// LoadSkillMetrics fills in the Metrics of each skill from its linked
+// projects and experiences. Ongoing experiences count up to the current
+// month. Projects have no dates of their own, so only experiences set
+// LastUsed.
+func (db *DB) LoadSkillMetrics(skills []models.Skill) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	index := make(map[int64]int, len(skills))
+	for i := range skills {
+		skills[i].Metrics = models.SkillMetrics{}
+		index[skills[i].ID] = i
+	}
+
+	rows, err := db.conn().Query(`
+		SELECT su.skill_id, COUNT(*)
+		FROM skill_uses su
+		JOIN projects p ON p.id = su.project_id
+		WHERE p.deleted = 0 AND p.visibility != 'private'
+		GROUP BY su.skill_id`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var skillID int64
+		var count int
+		if err := rows.Scan(&skillID, &count); err != nil {
+			return err
+		}
+		if i, ok := index[skillID]; ok {
+			skills[i].Metrics.Projects = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.conn().Query(`
+		SELECT es.skill_id, e.start_date, e.end_date
+		FROM experience_skills es
+		JOIN experiences e ON e.id = es.experience_id
+		WHERE e.deleted = 0`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	now := monthIndex(time.Now())
+	spans := make(map[int][][2]int)
+	for rows.Next() {
+		var skillID int64
+		var start, end string
+		if err := rows.Scan(&skillID, &start, &end); err != nil {
+			return err
+		}
+		i, ok := index[skillID]
+		if !ok {
+			continue
+		}
+		from, err := time.Parse("2006-01", start)
+		if err != nil {
+			continue // unparseable dates don't count
+		}
+		to := now
+		if end == "" {
+			skills[i].Metrics.Current = true
+		} else if t, err := time.Parse("2006-01", end); err == nil {
+			to = monthIndex(t)
+		}
+		if to < monthIndex(from) {
+			continue
+		}
+		spans[i] = append(spans[i], [2]int{monthIndex(from), to})
+		if last := monthString(to); last > skills[i].Metrics.LastUsed {
+			skills[i].Metrics.LastUsed = last
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i, s := range spans {
+		skills[i].Metrics.Months = coveredMonths(s)
+	}
+	return nil
+}
+
+// coveredMonths counts the months in the inclusive spans, counting
+// overlapping months once.
+func coveredMonths(spans [][2]int) int {
+	slices.SortFunc(spans, func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) })
+	total, covered := 0, -1 // covered is the last month counted so far
+	for _, s := range spans {
+		from := max(s[0], covered+1)
+		if s[1] >= from {
+			total += s[1] - from + 1
+			covered = s[1]
+		}
+	}
+	return total
+}
+
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+func monthString(index int) string {
+	return time.Date(index/12, time.Month(index%12+1), 1, 0, 0, 0, 0, time.UTC).
+		Format("2006-01")
+}
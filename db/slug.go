@@ -0,0 +1,104 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Slugify lowercases s and collapses every run of characters that are
+// not letters or digits into a single hyphen.
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// uniqueProjectSlug slugifies slug (or title when slug is empty) and
+// appends -2, -3, ... until no other project uses it. excludeID is the
+// project being updated, or 0 for a new one.
+func (db *DB) uniqueProjectSlug(slug, title string, excludeID int64) (string, error) {
+	return db.uniqueSlug("projects", "project", slug, title, excludeID)
+}
+
+// uniqueSlug is uniqueProjectSlug for any table with id and slug
+// columns. fallback is used when neither slug nor title gives one, and
+// reserved slugs are treated as taken.
+func (db *DB) uniqueSlug(table, fallback, slug, title string, excludeID int64, reserved ...string) (string, error) {
+	base := Slugify(slug)
+	if base == "" {
+		base = Slugify(title)
+	}
+	if base == "" {
+		base = fallback
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		taken := slices.Contains(reserved, candidate)
+		if !taken {
+			var id int64
+			err := db.conn().QueryRow(
+				`SELECT id FROM `+table+` WHERE slug = ? AND id != ?`,
+				candidate, excludeID,
+			).Scan(&id)
+			if err == sql.ErrNoRows {
+				return candidate, nil
+			}
+			if err != nil {
+				return "", err
+			}
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// backfillProjectSlugs gives every project created before slugs existed
+// one derived from its title.
+func (db *DB) backfillProjectSlugs() error {
+	rows, err := db.conn().Query(`SELECT id, title FROM projects WHERE slug = ''`)
+	if err != nil {
+		return err
+	}
+	type pending struct {
+		id    int64
+		title string
+	}
+	var todo []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.title); err != nil {
+			rows.Close()
+			return err
+		}
+		todo = append(todo, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range todo {
+		slug, err := db.uniqueProjectSlug("", p.title, p.id)
+		if err != nil {
+			return err
+		}
+		if _, err := db.conn().Exec(
+			`UPDATE projects SET slug = ? WHERE id = ?`, slug, p.id,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Tags ====================
+//
+// Tags are shared by blog posts, projects and experiences. A tagging
+// links a tag to one item of a kind (models.TagKindPost, ...); the items
+// live in different tables, so taggings carry no foreign key to them and
+// queries join on kind and item_id instead.
+
+// setTags replaces the tags of an item, creating tags as needed and
+// dropping tags nothing carries any more.
+func setTags(tx *txn, kind string, itemID int64, names []string) error {
+	if _, err := tx.Exec(
+		`DELETE FROM taggings WHERE kind = ? AND item_id = ?`, kind, itemID,
+	); err != nil {
+		return err
+	}
+	for i, name := range names {
+		if _, err := tx.Exec(
+			`INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, name,
+		); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO taggings (tag_id, kind, item_id, sort_order)
+			SELECT id, ?, ?, ? FROM tags WHERE name = ?`,
+			kind, itemID, i, name,
+		); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(`
+		DELETE FROM tags
+		WHERE id NOT IN (SELECT tag_id FROM taggings)`,
+	)
+	return err
+}
+
+// SetTags replaces the tags of an item.
+func (db *DB) SetTags(kind string, itemID int64, names []string) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setTags(tx, kind, itemID, names); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetTags returns the tag names of one item, in the order they were
+// entered.
+func (db *DB) GetTags(kind string, itemID int64) ([]string, error) {
+	tags, err := db.tagsByItem(kind, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return tags[itemID], nil
+}
+
+// tagsByItem maps items of a kind to their tag names. With no itemIDs it
+// loads every item of the kind.
+func (db *DB) tagsByItem(kind string, itemIDs ...int64) (map[int64][]string, error) {
+	query := `
+		SELECT tg.item_id, t.name
+		FROM taggings tg
+		JOIN tags t ON t.id = tg.tag_id
+		WHERE tg.kind = ?`
+	args := []any{kind}
+	if len(itemIDs) > 0 {
+		query += ` AND tg.item_id IN (?` + strings.Repeat(", ?", len(itemIDs)-1) + `)`
+		for _, id := range itemIDs {
+			args = append(args, id)
+		}
+	}
+	rows, err := db.conn().Query(query+` ORDER BY tg.item_id, tg.sort_order`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]string)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		tags[id] = append(tags[id], name)
+	}
+	return tags, rows.Err()
+}
+
+const tagColumns = `
+		t.id, t.name,
+		(SELECT COUNT(*) FROM taggings tg WHERE tg.tag_id = t.id)`
+
+func scanTag(sc scanner) (models.Tag, error) {
+	var t models.Tag
+	err := sc.Scan(&t.ID, &t.Name, &t.Count)
+	return t, err
+}
+
+// GetTagByName looks up a tag, ignoring case.
+func (db *DB) GetTagByName(name string) (*models.Tag, error) {
+	t, err := scanTag(db.conn().QueryRow(`
+		SELECT`+tagColumns+`
+		FROM tags t
+		WHERE t.name = ?`, name,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SuggestTags returns up to limit tags starting with prefix, most used
+// first. An empty prefix suggests the most used tags.
+func (db *DB) SuggestTags(prefix string, limit int) ([]models.Tag, error) {
+	escaped := likeEscaper.Replace(prefix)
+	rows, err := db.conn().Query(`
+		SELECT`+tagColumns+`
+		FROM tags t
+		WHERE t.name LIKE ? ESCAPE '\'
+		ORDER BY 3 DESC, t.name
+		LIMIT ?`, escaped+"%", limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		t, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// GetPostsTagged returns the live posts carrying a tag, newest first.
+func (db *DB) GetPostsTagged(tagID int64) ([]models.BlogPost, error) {
+	return db.queryPosts(`
+		SELECT`+postColumns+`
+		FROM blog_posts b
+		JOIN taggings tg ON tg.kind = ? AND tg.item_id = b.id
+		WHERE tg.tag_id = ? AND `+livePost+`
+		ORDER BY `+postDate+` DESC`, models.TagKindPost, tagID,
+	)
+}
+
+// GetProjectsTagged returns the listed projects carrying a tag, in the
+// same order as GetListedProjects.
+func (db *DB) GetProjectsTagged(tagID int64) ([]models.Project, error) {
+	return db.queryProjects(`
+		SELECT`+projectColumns+`
+		FROM projects p
+		JOIN taggings tg ON tg.kind = ? AND tg.item_id = p.id
+		WHERE tg.tag_id = ? AND p.deleted = 0 AND `+listedProject+`
+		ORDER BY p.visibility = 'featured' DESC, p.created_at DESC`,
+		models.TagKindProject, tagID,
+	)
+}
+
+// GetExperiencesTagged returns the experiences carrying a tag, newest
+// first, with their details.
+func (db *DB) GetExperiencesTagged(tagID int64) ([]models.Experience, error) {
+	rows, err := db.conn().Query(`
+		SELECT`+experienceColumns+`
+		FROM experiences
+		JOIN taggings tg ON tg.kind = ? AND tg.item_id = experiences.id
+		WHERE tg.tag_id = ? AND deleted = 0
+		ORDER BY start_date DESC`, models.TagKindExperience, tagID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var exps []models.Experience
+	for rows.Next() {
+		e, err := scanExperience(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		exps = append(exps, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := db.loadExperienceDetails(exps); err != nil {
+		return nil, err
+	}
+	return exps, nil
+}
+
+// migrateTags moves the comma-separated tags of posts written before
+// the taggings table existed into it. Posts that already have taggings
+// are left alone.
+func (db *DB) migrateTags() error {
+	rows, err := db.conn().Query(`
+		SELECT id, tags FROM blog_posts
+		WHERE tags != '' AND id NOT IN (
+			SELECT item_id FROM taggings WHERE kind = ?
+		)`, models.TagKindPost,
+	)
+	if err != nil {
+		return err
+	}
+	pending := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var tags string
+		if err := rows.Scan(&id, &tags); err != nil {
+			rows.Close()
+			return err
+		}
+		pending[id] = tags
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, tags := range pending {
+		if err := db.SetTags(models.TagKindPost, id, models.SplitTags(tags)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ==================== Resume Variants ====================
+
+// reservedVariantSlugs would collide with fixed routes under /resume/.
+var reservedVariantSlugs = []string{"pdf"}
+
+func scanVariant(sc scanner) (models.ResumeVariant, error) {
+	var v models.ResumeVariant
+	var sections string
+	if err := sc.Scan(
+		&v.ID, &v.Slug, &v.Name, &v.Headline, &v.Summary, &sections,
+		&v.CreatedAt, &v.UpdatedAt,
+	); err != nil {
+		return v, err
+	}
+	if sections != "" {
+		v.Sections = strings.Split(sections, ",")
+	}
+	return v, nil
+}
+
+const variantColumns = `
+		id, slug, name, headline, summary, sections, created_at, updated_at`
+
+func (db *DB) GetResumeVariants() ([]models.ResumeVariant, error) {
+	rows, err := db.conn().Query(`
+		SELECT` + variantColumns + `
+		FROM resume_variants
+		ORDER BY name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var variants []models.ResumeVariant
+	for rows.Next() {
+		v, err := scanVariant(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range variants {
+		if err := db.loadVariantItems(&variants[i]); err != nil {
+			return nil, err
+		}
+	}
+	return variants, nil
+}
+
+func (db *DB) GetResumeVariantByID(id int64) (*models.ResumeVariant, error) {
+	return db.getResumeVariant(`WHERE id = ?`, id)
+}
+
+func (db *DB) GetResumeVariantBySlug(slug string) (*models.ResumeVariant, error) {
+	return db.getResumeVariant(`WHERE slug = ?`, slug)
+}
+
+func (db *DB) getResumeVariant(where string, arg any) (*models.ResumeVariant, error) {
+	v, err := scanVariant(db.conn().QueryRow(`
+		SELECT`+variantColumns+`
+		FROM resume_variants `+where, arg,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err := db.loadVariantItems(&v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// loadVariantItems fills in the variant's selected item IDs.
+func (db *DB) loadVariantItems(v *models.ResumeVariant) error {
+	rows, err := db.conn().Query(`
+		SELECT kind, item_id FROM resume_variant_items
+		WHERE variant_id = ?
+		ORDER BY kind, item_id`, v.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var kind string
+		var id int64
+		if err := rows.Scan(&kind, &id); err != nil {
+			return err
+		}
+		if ids := variantItemIDs(v, kind); ids != nil {
+			*ids = append(*ids, id)
+		}
+	}
+	return rows.Err()
+}
+
+// variantItemIDs returns the ID list of v that holds items of kind.
+func variantItemIDs(v *models.ResumeVariant, kind string) *[]int64 {
+	switch kind {
+	case models.SectionExperience:
+		return &v.ExperienceIDs
+	case models.SectionSkills:
+		return &v.SkillIDs
+	case models.SectionProjects:
+		return &v.ProjectIDs
+	case models.SectionEducation:
+		return &v.EducationIDs
+	}
+	return nil
+}
+
+// CreateResumeVariant stores v and its item selections. The slug is made
+// unique the same way as project slugs.
+func (db *DB) CreateResumeVariant(v models.ResumeVariant) (int64, error) {
+	slug, err := db.uniqueSlug(
+		"resume_variants", "variant", v.Slug, v.Name, 0,
+		reservedVariantSlugs...,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		INSERT INTO resume_variants (slug, name, headline, summary, sections)
+		VALUES (?, ?, ?, ?, ?)`,
+		slug, v.Name, v.Headline, v.Summary, strings.Join(v.Sections, ","),
+	)
+	if err != nil {
+		return 0, err
+	}
+	v.ID, err = res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := writeVariantItems(tx, v); err != nil {
+		return 0, err
+	}
+	return v.ID, tx.Commit()
+}
+
+func (db *DB) UpdateResumeVariant(v models.ResumeVariant) error {
+	slug, err := db.uniqueSlug(
+		"resume_variants", "variant", v.Slug, v.Name, v.ID,
+		reservedVariantSlugs...,
+	)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE resume_variants
+		SET slug = ?, name = ?, headline = ?, summary = ?, sections = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`,
+		slug, v.Name, v.Headline, v.Summary, strings.Join(v.Sections, ","),
+		v.ID,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		`DELETE FROM resume_variant_items WHERE variant_id = ?`, v.ID,
+	); err != nil {
+		return err
+	}
+	if err := writeVariantItems(tx, v); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func writeVariantItems(tx *txn, v models.ResumeVariant) error {
+	for _, kind := range []string{
+		models.SectionExperience, models.SectionSkills,
+		models.SectionProjects, models.SectionEducation,
+	} {
+		for _, id := range *variantItemIDs(&v, kind) {
+			if _, err := tx.Exec(`
+				INSERT OR IGNORE INTO resume_variant_items
+					(variant_id, kind, item_id)
+				VALUES (?, ?, ?)`, v.ID, kind, id,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteResumeVariant removes a variant; its item selections go with it.
+func (db *DB) DeleteResumeVariant(id int64) error {
+	_, err := db.conn().Exec(`DELETE FROM resume_variants WHERE id = ?`, id)
+	return err
+}
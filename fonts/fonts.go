@@ -0,0 +1,78 @@
+// Package fonts bundles the TrueType fonts used for generated PDFs so
+// that any UTF-8 text (accents, em dashes, curly quotes, non-Latin
+// scripts) renders correctly. See LICENSE for the font license.
+package fonts
+
+import (
+	"embed"
+
+	"github.com/go-pdf/fpdf"
+)
+
+//go:embed *.ttf
+var files embed.FS
+
+// Family is a bundled font family. Italic may be empty, in which case
+// the regular face is used for italic text as well.
+type Family struct {
+	Name    string
+	Label   string
+	Regular string
+	Bold    string
+	Italic  string
+}
+
+const (
+	SansCondensed = "dejavu-sans-condensed"
+	Serif         = "dejavu-serif"
+)
+
+// Families lists the bundled families in the order they are offered in
+// the admin panel.
+var Families = []Family{
+	{
+		Name:    SansCondensed,
+		Label:   "DejaVu Sans Condensed",
+		Regular: "DejaVuSansCondensed.ttf",
+		Bold:    "DejaVuSansCondensed-Bold.ttf",
+		Italic:  "DejaVuSansCondensed-Oblique.ttf",
+	},
+	{
+		Name:    Serif,
+		Label:   "DejaVu Serif",
+		Regular: "DejaVuSerif.ttf",
+		Bold:    "DejaVuSerif-Bold.ttf",
+		// No italic face is bundled; italic text is set upright.
+	},
+}
+
+func Lookup(name string) (Family, bool) {
+	for _, f := range Families {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Family{}, false
+}
+
+// Register adds the family's regular, bold and italic faces to pdf under
+// f.Name, so it can be selected with pdf.SetFont(f.Name, style, size).
+func (f Family) Register(pdf *fpdf.Fpdf) {
+	italic := f.Italic
+	if italic == "" {
+		italic = f.Regular
+	}
+	faces := []struct{ style, file string }{
+		{"", f.Regular},
+		{"B", f.Bold},
+		{"I", italic},
+	}
+	for _, face := range faces {
+		b, err := files.ReadFile(face.file)
+		if err != nil {
+			pdf.SetError(err)
+			return
+		}
+		pdf.AddUTF8FontFromBytes(f.Name, face.style, b)
+	}
+}
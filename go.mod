@@ -4,12 +4,13 @@ go 1.25
 
 require (
 	github.com/a-h/templ v0.3.977
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/labstack/echo/v4 v4.15.0
 	github.com/mattn/go-sqlite3 v1.14.34
+	github.com/yuin/goldmark v1.8.6
 )
 
 require (
-	github.com/go-pdf/fpdf v0.9.0 // indirect
 	github.com/labstack/gommon v0.4.2 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
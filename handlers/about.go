@@ -4,6 +4,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/DYankee/resume2/db"
 	"github.com/DYankee/resume2/models"
@@ -16,29 +17,71 @@ type AboutHandler struct {
 }
 
 func (h *AboutHandler) HandleAboutPage(c echo.Context) error {
-	skills, err := h.DB.GetAllSkills()
+	skills, err := h.DB.GetListedSkills()
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load skills")
 	}
+	featured, err := h.DB.GetFeaturedProjects()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load projects")
+	}
 	experiences, err := h.DB.GetAllExperiences()
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load experiences")
 	}
+	for i := range experiences {
+		experiences[i].Skills = db.ListedSkills(experiences[i].Skills)
+	}
 	education, err := h.DB.GetAllEducation()
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load education")
 	}
+	for i := range education {
+		education[i].Projects = notPrivateProjects(education[i].Projects)
+	}
 	categories, err := h.DB.GetAllSkillCategories()
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load categories")
 	}
+	certifications, err := h.DB.GetAllCertifications()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load certifications")
+	}
+	certifications = currentCertifications(certifications, time.Now())
+	awards, err := h.DB.GetAllAwards()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load awards")
+	}
+	publications, err := h.DB.GetAllPublications()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load publications")
+	}
 
+	sections, err := h.DB.GetSectionOrder(models.SurfaceAbout)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load sections")
+	}
+	custom, err := h.DB.GetAllCustomSections()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load sections")
+	}
+
+	d := pages.AboutData{
+		Sections:       sections,
+		Skills:         skills,
+		Featured:       projectsWithSkills(h.DB, featured),
+		Experiences:    experiences,
+		Education:      education,
+		Categories:     categories,
+		Certifications: certifications,
+		Awards:         awards,
+		Publications:   publications,
+		Custom:         custom,
+	}
 	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.AboutContent(skills, experiences, education, categories).
-			Render(c.Request().Context(), c.Response())
+		return pages.AboutContent(d).Render(c.Request().Context(), c.Response())
 	}
-	return pages.AboutPage(skills, experiences, education, categories).
-		Render(c.Request().Context(), c.Response())
+	return pages.AboutPage(d).Render(c.Request().Context(), c.Response())
 }
 
 func (h *AboutHandler) HandleSkillDetail(c echo.Context) error {
@@ -47,9 +90,13 @@ func (h *AboutHandler) HandleSkillDetail(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "Invalid skill ID")
 	}
 	skill, err := h.DB.GetSkillByID(id)
-	if err != nil {
+	if err != nil || skill.Visibility == models.VisibilityPrivate {
 		return c.String(http.StatusNotFound, "Skill not found")
 	}
+	skills := []models.Skill{*skill}
+	if err := h.DB.LoadSkillMetrics(skills); err == nil {
+		skill = &skills[0]
+	}
 	project, _ := h.DB.GetRandomProjectForSkill(id) // nil is fine
 	return pages.SkillDetail(skill, project).
 		Render(c.Request().Context(), c.Response())
@@ -62,13 +109,13 @@ func (h *AboutHandler) HandleFilteredSkills(c echo.Context) error {
 	var err error
 
 	if catIDStr == "" || catIDStr == "all" {
-		skills, err = h.DB.GetAllSkills()
+		skills, err = h.DB.GetListedSkills()
 	} else {
 		catID, parseErr := strconv.ParseInt(catIDStr, 10, 64)
 		if parseErr != nil {
 			return c.String(http.StatusBadRequest, "Invalid category ID")
 		}
-		skills, err = h.DB.GetSkillsByCategoryID(catID)
+		skills, err = h.DB.GetListedSkillsByCategoryID(catID)
 	}
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load skills")
@@ -2,45 +2,81 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/fonts"
+	"github.com/DYankee/resume2/models"
 	"github.com/DYankee/resume2/templates/pages"
 	"github.com/labstack/echo/v4"
 )
 
 type AdminHandler struct {
-	DB *db.DB
+	DB        *db.DB
+	Scheduler *PostScheduler
 }
 
 // ── Dashboard ─────────────────────────────────────
 
+// certExpiryWarningMonths is how far ahead the dashboard warns about
+// expiring certifications.
+const certExpiryWarningMonths = 3
+
 func (h *AdminHandler) HandleDashboard(c echo.Context) error {
 	skills, _ := h.DB.GetAllSkills()
 	projects, _ := h.DB.GetAllProjects()
 	experiences, _ := h.DB.GetAllExperiences()
 	education, _ := h.DB.GetAllEducation()
+	certs, _ := h.DB.GetAllCertifications()
+
+	now := time.Now()
+	var alerts []pages.CertificationAlert
+	for _, cert := range certs {
+		if cert.Expired(now) || cert.ExpiresWithin(now, certExpiryWarningMonths) {
+			alerts = append(alerts, pages.CertificationAlert{
+				Certification: cert,
+				Expired:       cert.Expired(now),
+			})
+		}
+	}
+	slices.SortFunc(alerts, func(a, b pages.CertificationAlert) int {
+		return strings.Compare(a.Certification.ExpiryDate, b.Certification.ExpiryDate)
+	})
 
 	if c.Request().Header.Get("HX-Request") == "true" {
 		return pages.AdminDashboardContent(
 			len(skills), len(projects), len(experiences), len(education),
+			alerts,
 		).Render(c.Request().Context(), c.Response())
 	}
 	return pages.AdminDashboardPage(
 		len(skills), len(projects), len(experiences), len(education),
+		alerts,
 	).Render(c.Request().Context(), c.Response())
 }
 
 // ── Skills ────────────────────────────────────────
 
 func (h *AdminHandler) HandleAdminSkills(c echo.Context) error {
-	skills, err := h.DB.GetAllSkills()
+	skills, err := h.DB.GetAllSkillsWithAliases()
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError, "Failed to load skills",
 		)
 	}
+	if err := h.DB.LoadSkillMetrics(skills); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load skill metrics",
+		)
+	}
 	categories, err := h.DB.GetAllSkillCategories()
 	if err != nil {
 		return c.String(
@@ -49,10 +85,10 @@ func (h *AdminHandler) HandleAdminSkills(c echo.Context) error {
 	}
 
 	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.AdminSkillsContent(skills, categories).
+		return pages.AdminSkillsContent(skills, likelyDuplicates(skills), categories).
 			Render(c.Request().Context(), c.Response())
 	}
-	return pages.AdminSkillsPage(skills, categories).
+	return pages.AdminSkillsPage(skills, likelyDuplicates(skills), categories).
 		Render(c.Request().Context(), c.Response())
 }
 
@@ -74,6 +110,12 @@ func (h *AdminHandler) HandleAdminSkillForm(c echo.Context) error {
 	if err != nil {
 		return c.String(http.StatusNotFound, "Skill not found")
 	}
+	skill.Aliases, err = h.DB.GetSkillAliases(id)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load aliases",
+		)
+	}
 	return pages.SkillForm(skill, categories).
 		Render(c.Request().Context(), c.Response())
 }
@@ -86,19 +128,33 @@ func (h *AdminHandler) HandleCreateSkill(c echo.Context) error {
 	proficiency, _ := strconv.ParseInt(
 		c.FormValue("proficiency"), 10, 8,
 	)
+	visibility := c.FormValue("visibility")
 
 	if name == "" || categoryID == 0 {
 		return c.String(http.StatusBadRequest, "Name and category required")
 	}
+	if !models.ValidVisibility(visibility) {
+		return c.String(http.StatusBadRequest, "Invalid visibility")
+	}
+	aliases, err := h.skillAliasesFromForm(c, 0)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
 
-	_, err := h.DB.CreateSkill(
+	id, err := h.DB.CreateSkill(
 		name, categoryID, description, iconURL, int8(proficiency),
+		visibility,
 	)
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError, "Failed to create skill",
 		)
 	}
+	if err := h.DB.SetSkillAliases(id, name, aliases); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to save aliases",
+		)
+	}
 
 	c.Response().Header().Set("HX-Trigger", "refreshSkills")
 	return c.String(http.StatusOK, "")
@@ -117,15 +173,30 @@ func (h *AdminHandler) HandleUpdateSkill(c echo.Context) error {
 	proficiency, _ := strconv.ParseInt(
 		c.FormValue("proficiency"), 10, 8,
 	)
+	visibility := c.FormValue("visibility")
+
+	if !models.ValidVisibility(visibility) {
+		return c.String(http.StatusBadRequest, "Invalid visibility")
+	}
+	aliases, err := h.skillAliasesFromForm(c, id)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
 
 	err = h.DB.UpdateSkill(
 		id, name, categoryID, description, iconURL, int8(proficiency),
+		visibility,
 	)
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError, "Failed to update skill",
 		)
 	}
+	if err := h.DB.SetSkillAliases(id, name, aliases); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to save aliases",
+		)
+	}
 
 	c.Response().Header().Set("HX-Trigger", "refreshSkills")
 	return c.String(http.StatusOK, "")
@@ -149,25 +220,114 @@ func (h *AdminHandler) HandleDeleteSkill(c echo.Context) error {
 }
 
 func (h *AdminHandler) HandleAdminSkillsTable(c echo.Context) error {
-	skills, err := h.DB.GetAllSkills()
+	skills, err := h.DB.GetAllSkillsWithAliases()
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError, "Failed to load skills",
 		)
 	}
-	return pages.SkillsTable(skills).
+	if err := h.DB.LoadSkillMetrics(skills); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load skill metrics",
+		)
+	}
+	return pages.SkillsTable(skills, likelyDuplicates(skills)).
 		Render(c.Request().Context(), c.Response())
 }
 
+// skillAliasesFromForm reads the comma-separated aliases. An alias may
+// not name a skill other than skillID.
+func (h *AdminHandler) skillAliasesFromForm(c echo.Context, skillID int64) ([]string, error) {
+	var aliases []string
+	for _, a := range strings.Split(c.FormValue("aliases"), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			aliases = append(aliases, a)
+		}
+	}
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	skills, err := h.DB.GetAllSkillsWithAliases()
+	if err != nil {
+		return nil, errors.New("Failed to check aliases")
+	}
+	for _, a := range aliases {
+		for _, s := range skills {
+			if s.ID != skillID && s.Answers(a) {
+				return nil, fmt.Errorf("Alias %q already names %s", a, s.Name)
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// likelyDuplicates maps each skill that shares a name or alias with
+// another skill to that skill's name.
+func likelyDuplicates(skills []models.Skill) map[int64]string {
+	duplicates := make(map[int64]string)
+	seen := make(map[string]models.Skill) // normalized name -> first skill
+	for _, s := range skills {
+		keys := []string{models.NormalizeSkillName(s.Name)}
+		for _, a := range s.Aliases {
+			keys = append(keys, models.NormalizeSkillName(a))
+		}
+		for _, key := range keys {
+			other, ok := seen[key]
+			if !ok {
+				seen[key] = s
+				continue
+			}
+			if other.ID == s.ID {
+				continue
+			}
+			if _, ok := duplicates[s.ID]; !ok {
+				duplicates[s.ID] = other.Name
+			}
+			if _, ok := duplicates[other.ID]; !ok {
+				duplicates[other.ID] = s.Name
+			}
+		}
+	}
+	return duplicates
+}
+
 // ── Skill Categories ──────────────────────────────
 
-func (h *AdminHandler) HandleCreateCategory(c echo.Context) error {
-	name := c.FormValue("name")
+func (h *AdminHandler) HandleAdminCategoriesTable(c echo.Context) error {
+	categories, err := h.DB.GetAllSkillCategories()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load categories",
+		)
+	}
+	return pages.CategoriesTable(categories).
+		Render(c.Request().Context(), c.Response())
+}
+
+// categoryNameFromForm reads the category name, which must not clash
+// with a category other than excludeID.
+func (h *AdminHandler) categoryNameFromForm(c echo.Context, excludeID int64) (string, error) {
+	name := strings.TrimSpace(c.FormValue("name"))
 	if name == "" {
-		return c.String(http.StatusBadRequest, "Name required")
+		return "", errors.New("Name required")
+	}
+	taken, err := h.DB.SkillCategoryNameTaken(name, excludeID)
+	if err != nil {
+		return "", errors.New("Failed to check category name")
+	}
+	if taken {
+		return "", errors.New("A category with that name already exists")
+	}
+	return name, nil
+}
+
+func (h *AdminHandler) HandleCreateCategory(c echo.Context) error {
+	name, err := h.categoryNameFromForm(c, 0)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
 	}
 
-	_, err := h.DB.CreateSkillCategory(name)
+	_, err = h.DB.CreateSkillCategory(name)
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError, "Failed to create category",
@@ -178,6 +338,141 @@ func (h *AdminHandler) HandleCreateCategory(c echo.Context) error {
 	return c.String(http.StatusOK, "Category created")
 }
 
+func (h *AdminHandler) HandleAdminCategoryForm(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+	category, err := h.DB.GetSkillCategoryByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Category not found")
+	}
+	return pages.CategoryForm(category).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleUpdateCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	name, err := h.categoryNameFromForm(c, id)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.DB.UpdateSkillCategory(id, name); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to update category",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshSkills")
+	return c.String(http.StatusOK, "")
+}
+
+// HandleAdminCategoryMergeForm offers the other categories to move this
+// one's skills into.
+func (h *AdminHandler) HandleAdminCategoryMergeForm(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+	category, err := h.DB.GetSkillCategoryByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Category not found")
+	}
+	categories, err := h.DB.GetAllSkillCategories()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load categories",
+		)
+	}
+
+	var targets []models.Skill_category
+	for _, t := range categories {
+		if t.ID != id {
+			targets = append(targets, t)
+		}
+	}
+	return pages.CategoryMergeForm(category, targets).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleMergeCategory moves every skill in the category into the one
+// named by "into" and removes the emptied category.
+func (h *AdminHandler) HandleMergeCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	into, _ := strconv.ParseInt(c.FormValue("into"), 10, 64)
+	if into == 0 || into == id {
+		return c.String(
+			http.StatusBadRequest, "Choose a category to merge into",
+		)
+	}
+	if _, err := h.DB.GetSkillCategoryByID(into); err != nil {
+		return c.String(http.StatusBadRequest, "Unknown category")
+	}
+
+	if err := h.DB.MergeSkillCategories(id, into); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to merge category",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshSkills")
+	return c.String(http.StatusOK, "")
+}
+
+// HandleDeleteCategory removes a category. Categories that still hold
+// skills must be merged into another instead.
+func (h *AdminHandler) HandleDeleteCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	err = h.DB.DeleteSkillCategory(id)
+	if errors.Is(err, db.ErrCategoryInUse) {
+		return c.String(
+			http.StatusConflict,
+			"Merge this category into another to move its skills first",
+		)
+	}
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to delete category",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshSkills")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleMoveCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	delta := 1
+	if c.QueryParam("dir") == "up" {
+		delta = -1
+	}
+	if err := h.DB.MoveSkillCategory(id, delta); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to move category",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshSkills")
+	return c.String(http.StatusOK, "")
+}
+
 // ── Projects ──────────────────────────────────────
 
 func (h *AdminHandler) HandleAdminProjects(c echo.Context) error {
@@ -202,7 +497,7 @@ func (h *AdminHandler) HandleAdminProjectForm(c echo.Context) error {
 
 	idStr := c.Param("id")
 	if idStr == "" {
-		return pages.ProjectForm(nil, skills, nil).
+		return pages.ProjectForm(nil, skills, nil, nil).
 			Render(c.Request().Context(), c.Response())
 	}
 
@@ -215,24 +510,31 @@ func (h *AdminHandler) HandleAdminProjectForm(c echo.Context) error {
 		return c.String(http.StatusNotFound, "Project not found")
 	}
 	projectSkills, _ := h.DB.GetSkillsForProject(id)
-	return pages.ProjectForm(project, skills, projectSkills).
+	media, _ := h.DB.GetMediaForProject(id)
+	return pages.ProjectForm(project, skills, projectSkills, media).
 		Render(c.Request().Context(), c.Response())
 }
 
 func (h *AdminHandler) HandleCreateProject(c echo.Context) error {
 	title := c.FormValue("title")
+	slug := c.FormValue("slug")
 	description := c.FormValue("description")
 	longDesc := c.FormValue("long_desc")
 	imageURL := c.FormValue("image_url")
 	repoURL := c.FormValue("repo_url")
 	liveURL := c.FormValue("live_url")
+	visibility := c.FormValue("visibility")
 
 	if title == "" {
 		return c.String(http.StatusBadRequest, "Title required")
 	}
+	if !models.ValidVisibility(visibility) {
+		return c.String(http.StatusBadRequest, "Invalid visibility")
+	}
 
 	projectID, err := h.DB.CreateProject(
-		title, description, longDesc, imageURL, repoURL, liveURL,
+		slug, title, description, longDesc, imageURL, repoURL, liveURL,
+		visibility,
 	)
 	if err != nil {
 		return c.String(
@@ -249,6 +551,13 @@ func (h *AdminHandler) HandleCreateProject(c echo.Context) error {
 		}
 	}
 
+	tags := models.SplitTags(c.FormValue("tags"))
+	if err := h.DB.SetTags(models.TagKindProject, projectID, tags); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to save tags",
+		)
+	}
+
 	c.Response().Header().Set("HX-Trigger", "refreshProjects")
 	return c.String(http.StatusOK, "")
 }
@@ -260,14 +569,21 @@ func (h *AdminHandler) HandleUpdateProject(c echo.Context) error {
 	}
 
 	title := c.FormValue("title")
+	slug := c.FormValue("slug")
 	description := c.FormValue("description")
 	longDesc := c.FormValue("long_desc")
 	imageURL := c.FormValue("image_url")
 	repoURL := c.FormValue("repo_url")
 	liveURL := c.FormValue("live_url")
+	visibility := c.FormValue("visibility")
+
+	if !models.ValidVisibility(visibility) {
+		return c.String(http.StatusBadRequest, "Invalid visibility")
+	}
 
 	err = h.DB.UpdateProject(
-		id, title, description, longDesc, imageURL, repoURL, liveURL,
+		id, slug, title, description, longDesc, imageURL, repoURL, liveURL,
+		visibility,
 	)
 	if err != nil {
 		return c.String(
@@ -288,6 +604,13 @@ func (h *AdminHandler) HandleUpdateProject(c echo.Context) error {
 		}
 	}
 
+	tags := models.SplitTags(c.FormValue("tags"))
+	if err := h.DB.SetTags(models.TagKindProject, id, tags); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to save tags",
+		)
+	}
+
 	c.Response().Header().Set("HX-Trigger", "refreshProjects")
 	return c.String(http.StatusOK, "")
 }
@@ -320,239 +643,1960 @@ func (h *AdminHandler) HandleAdminProjectsTable(c echo.Context) error {
 		Render(c.Request().Context(), c.Response())
 }
 
-// ── Experience ──────────────────────────────────────
-func (h *AdminHandler) HandleAdminExperience(c echo.Context) error {
-	experience, err := h.DB.GetAllExperiences()
+// ── Project Media ─────────────────────────────────
+
+func (h *AdminHandler) renderMediaEditor(c echo.Context, projectID int64) error {
+	media, err := h.DB.GetMediaForProject(projectID)
 	if err != nil {
 		return c.String(
-			http.StatusInternalServerError, "Failed to load Experiences",
+			http.StatusInternalServerError, "Failed to load media",
 		)
 	}
-
-	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.AdminExperienceContent(experience).
-			Render(c.Request().Context(), c.Response())
-	}
-	return pages.AdminExperiencePage(experience).
+	return pages.MediaEditor(projectID, media).
 		Render(c.Request().Context(), c.Response())
 }
 
-func (h *AdminHandler) HandleAdminExperienceForm(c echo.Context) error {
-
-	idStr := c.Param("id")
-	if idStr == "" {
-		// New skill form
-		return pages.ExperienceForm(nil).
-			Render(c.Request().Context(), c.Response())
-	}
-
-	id, err := strconv.ParseInt(idStr, 10, 64)
+func (h *AdminHandler) HandleAdminProjectMedia(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid experience ID")
+		return c.String(http.StatusBadRequest, "Invalid project ID")
 	}
-	experience, err := h.DB.GetExperienceByID(id)
+	return h.renderMediaEditor(c, projectID)
+}
+
+func (h *AdminHandler) HandleCreateProjectMedia(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusNotFound, "Experience not found")
+		return c.String(http.StatusBadRequest, "Invalid project ID")
 	}
-	return pages.ExperienceForm(experience).
-		Render(c.Request().Context(), c.Response())
-}
 
-func (h *AdminHandler) HandleCreateExperience(c echo.Context) error {
-	title := c.FormValue("title")
-	company := c.FormValue("company")
-	description := c.FormValue("description")
-	startDate := c.FormValue("start_date")
-	endDate := c.FormValue("end_date")
+	kind := c.FormValue("kind")
+	url := c.FormValue("url")
+	caption := c.FormValue("caption")
+	altText := c.FormValue("alt_text")
 
-	if title == "" {
-		return c.String(http.StatusBadRequest, "Title required")
+	if url == "" {
+		return c.String(http.StatusBadRequest, "URL required")
 	}
-	if company == "" {
-		return c.String(http.StatusBadRequest, "company required")
-	}
-	if startDate == "" {
-		return c.String(http.StatusBadRequest, "start Date required")
+	if !validMediaKind(kind) {
+		return c.String(http.StatusBadRequest, "Invalid media kind")
 	}
 
-	_, err := h.DB.CreateExperience(
-		title, company, startDate, endDate, description,
-	)
+	_, err = h.DB.AddProjectMedia(projectID, kind, url, caption, altText)
 	if err != nil {
 		return c.String(
-			http.StatusInternalServerError, "Failed to create project",
+			http.StatusInternalServerError, "Failed to add media",
 		)
 	}
-
-	c.Response().Header().Set("HX-Trigger", "refreshExperience")
-	return c.String(http.StatusOK, "")
+	return h.renderMediaEditor(c, projectID)
 }
 
-func (h *AdminHandler) HandleUpdateExperience(c echo.Context) error {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+func (h *AdminHandler) HandleUpdateProjectMedia(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid experience ID")
+		return c.String(http.StatusBadRequest, "Invalid project ID")
+	}
+	mediaID, err := strconv.ParseInt(c.Param("mediaID"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid media ID")
 	}
 
-	title := c.FormValue("title")
-	company := c.FormValue("company")
-	description := c.FormValue("description")
-	startDate := c.FormValue("start_date")
-	endDate := c.FormValue("end_date")
-
-	err = h.DB.UpdateExperience(
-		id, title, company, startDate, endDate, description,
+	err = h.DB.UpdateProjectMedia(
+		projectID, mediaID, c.FormValue("caption"), c.FormValue("alt_text"),
 	)
 	if err != nil {
 		return c.String(
-			http.StatusInternalServerError, "Failed to update Experience",
+			http.StatusInternalServerError, "Failed to update media",
 		)
 	}
-
-	c.Response().Header().Set("HX-Trigger", "refreshExperience")
-	return c.String(http.StatusOK, "")
+	return h.renderMediaEditor(c, projectID)
 }
 
-func (h *AdminHandler) HandleDeleteExperience(c echo.Context) error {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+func (h *AdminHandler) HandleMoveProjectMedia(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid experience ID")
+		return c.String(http.StatusBadRequest, "Invalid project ID")
 	}
-
-	err = h.DB.SoftDeleteExperience(id)
+	mediaID, err := strconv.ParseInt(c.Param("mediaID"), 10, 64)
 	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid media ID")
+	}
+
+	delta := 1
+	if c.QueryParam("dir") == "up" {
+		delta = -1
+	}
+	if err := h.DB.MoveProjectMedia(projectID, mediaID, delta); err != nil {
 		return c.String(
-			http.StatusInternalServerError, "Failed to delete project",
+			http.StatusInternalServerError, "Failed to move media",
 		)
 	}
-
-	c.Response().Header().Set("HX-Trigger", "refreshExperience")
-	return c.String(http.StatusOK, "")
+	return h.renderMediaEditor(c, projectID)
 }
 
-func (h *AdminHandler) HandleAdminExperienceTable(c echo.Context) error {
-	experience, err := h.DB.GetAllExperiences()
+func (h *AdminHandler) HandleDeleteProjectMedia(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid project ID")
+	}
+	mediaID, err := strconv.ParseInt(c.Param("mediaID"), 10, 64)
 	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid media ID")
+	}
+
+	if err := h.DB.DeleteProjectMedia(projectID, mediaID); err != nil {
 		return c.String(
-			http.StatusInternalServerError, "Failed to load experience",
+			http.StatusInternalServerError, "Failed to delete media",
 		)
 	}
-	return pages.ExperienceTable(experience).
-		Render(c.Request().Context(), c.Response())
+	return h.renderMediaEditor(c, projectID)
 }
 
-// ── Education ─────────────────────────────────────
+func validMediaKind(kind string) bool {
+	for _, k := range models.MediaKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
 
-func (h *AdminHandler) HandleAdminEducation(c echo.Context) error {
-	education, err := h.DB.GetAllEducation()
+// ── Tags ──────────────────────────────────────────
+
+// maxTagSuggestions caps the tags a TagInput offers at once.
+const maxTagSuggestions = 8
+
+// tagFieldID matches the element IDs TagInput accepts.
+var tagFieldID = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// HandleSuggestTags offers existing tags for the last, partly typed tag
+// of a TagInput, leaving out tags already entered.
+func (h *AdminHandler) HandleSuggestTags(c echo.Context) error {
+	id := c.QueryParam("id")
+	if !tagFieldID.MatchString(id) {
+		return c.String(http.StatusBadRequest, "Invalid field ID")
+	}
+
+	value := c.QueryParam("tags")
+	partial := ""
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		partial = value[i+1:]
+		value = value[:i]
+	} else {
+		partial, value = value, ""
+	}
+	entered := models.SplitTags(value)
+
+	tags, err := h.DB.SuggestTags(
+		strings.TrimSpace(partial), maxTagSuggestions+len(entered),
+	)
 	if err != nil {
 		return c.String(
-			http.StatusInternalServerError,
-			"Failed to load education",
+			http.StatusInternalServerError, "Failed to load tags",
 		)
 	}
-
-	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.AdminEducationContent(education).
-			Render(c.Request().Context(), c.Response())
+	var suggestions []pages.TagSuggestion
+	for _, t := range tags {
+		if slices.ContainsFunc(entered, func(e string) bool {
+			return strings.EqualFold(e, t.Name)
+		}) || len(suggestions) == maxTagSuggestions {
+			continue
+		}
+		suggestions = append(suggestions, pages.TagSuggestion{
+			Tag:  t,
+			Tags: strings.Join(append(slices.Clone(entered), t.Name), ", ") + ", ",
+		})
 	}
-	return pages.AdminEducationPage(education).
+	return pages.TagSuggestions(id, suggestions).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleTagField re-renders a TagInput with a picked suggestion filled
+// in.
+func (h *AdminHandler) HandleTagField(c echo.Context) error {
+	id := c.QueryParam("id")
+	if !tagFieldID.MatchString(id) {
+		return c.String(http.StatusBadRequest, "Invalid field ID")
+	}
+	return pages.TagInput(id, c.QueryParam("tags"), true).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Posts ─────────────────────────────────────────
+
+// Schedule times are entered and shown in the server's local time.
+const postTimeLayout = "2006-01-02T15:04"
+
+// postSchedule loads the posts and lays out the calendar for the month
+// starting at first.
+func (h *AdminHandler) postSchedule(first time.Time) (models.PostCalendar, []models.BlogPost, error) {
+	posts, err := h.DB.GetAllPosts()
+	if err != nil {
+		return models.PostCalendar{}, nil, err
+	}
+	return models.NewPostCalendar(first, posts), posts, nil
+}
+
+func (h *AdminHandler) HandleAdminPosts(c echo.Context) error {
+	now := time.Now()
+	cal, posts, err := h.postSchedule(
+		time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local),
+	)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load posts",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminPostsContent(cal, posts, now).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminPostsPage(cal, posts, now).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleAdminPostSchedule renders the calendar for ?month=YYYY-MM.
+func (h *AdminHandler) HandleAdminPostSchedule(c echo.Context) error {
+	first, err := time.ParseInLocation(
+		"2006-01", c.QueryParam("month"), time.Local,
+	)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid month")
+	}
+	cal, posts, err := h.postSchedule(first)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load posts",
+		)
+	}
+	return pages.PostSchedule(cal, posts, time.Now()).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminPostScheduleForm(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid post ID")
+	}
+	post, err := h.DB.GetPostByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Post not found")
+	}
+	return pages.PostScheduleForm(post).
+		Render(c.Request().Context(), c.Response())
+}
+
+// formPostTime reads a datetime-local input; empty gives the zero time.
+func formPostTime(c echo.Context, name, label string) (time.Time, error) {
+	v := strings.TrimSpace(c.FormValue(name))
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(postTimeLayout, v, time.Local)
+	if err != nil {
+		return t, errors.New(label + ": expected a date and time")
+	}
+	return t, nil
+}
+
+func (h *AdminHandler) HandleSchedulePost(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid post ID")
+	}
+
+	publishAt, err := formPostTime(c, "publish_at", "Publish at")
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	unpublishAt, err := formPostTime(c, "unpublish_at", "Unpublish at")
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if !unpublishAt.IsZero() && !publishAt.IsZero() &&
+		!unpublishAt.After(publishAt) {
+		return c.String(
+			http.StatusBadRequest,
+			"Unpublish at must be after publish at",
+		)
+	}
+
+	published := c.FormValue("published") == "true"
+	if err := h.DB.SchedulePost(id, published, publishAt, unpublishAt); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to schedule post",
+		)
+	}
+	h.Scheduler.Reschedule()
+
+	c.Response().Header().Set("HX-Trigger", "refreshPosts")
+	return c.String(http.StatusOK, "")
+}
+
+// ── Preview Links ─────────────────────────────────
+
+// previewDays are the lifetimes offered for a new preview link.
+var previewDays = []int{1, 7, 30}
+
+// previewLinkRows pairs links with their URLs for display.
+func (h *AdminHandler) previewLinkRows(links ...models.PreviewLink) ([]pages.PreviewLinkRow, error) {
+	secret, err := previewSecret(h.DB)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]pages.PreviewLinkRow, len(links))
+	for i, l := range links {
+		rows[i] = pages.PreviewLinkRow{Link: l, URL: previewURL(secret, l)}
+	}
+	return rows, nil
+}
+
+func (h *AdminHandler) loadPreviewLinks() ([]pages.PreviewLinkRow, error) {
+	links, err := h.DB.GetPreviewLinks()
+	if err != nil {
+		return nil, err
+	}
+	return h.previewLinkRows(links...)
+}
+
+func (h *AdminHandler) HandleAdminPreviews(c echo.Context) error {
+	rows, err := h.loadPreviewLinks()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load preview links",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminPreviewsContent(rows).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminPreviewsPage(rows).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminPreviewsTable(c echo.Context) error {
+	rows, err := h.loadPreviewLinks()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load preview links",
+		)
+	}
+	return pages.PreviewLinksTable(rows).
+		Render(c.Request().Context(), c.Response())
+}
+
+// previewItemTitle checks that a preview link's target exists and
+// returns its title.
+func (h *AdminHandler) previewItemTitle(kind string, itemID int64) (string, bool) {
+	switch kind {
+	case models.PreviewPost:
+		if post, err := h.DB.GetPostByID(itemID); err == nil {
+			return post.Title, true
+		}
+	case models.PreviewProject:
+		if project, err := h.DB.GetProjectByID(itemID); err == nil {
+			return project.Title, true
+		}
+	}
+	return "", false
+}
+
+// HandleAdminPreviewForm asks how long a new link to ?kind=&item_id=
+// should last.
+func (h *AdminHandler) HandleAdminPreviewForm(c echo.Context) error {
+	kind := c.QueryParam("kind")
+	itemID, err := strconv.ParseInt(c.QueryParam("item_id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid item ID")
+	}
+	title, ok := h.previewItemTitle(kind, itemID)
+	if !ok {
+		return c.String(http.StatusNotFound, "Item not found")
+	}
+	return pages.PreviewLinkForm(kind, itemID, title, previewDays).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleCreatePreview makes a link and shows its URL in the modal.
+func (h *AdminHandler) HandleCreatePreview(c echo.Context) error {
+	kind := c.FormValue("kind")
+	itemID, err := strconv.ParseInt(c.FormValue("item_id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid item ID")
+	}
+	if _, ok := h.previewItemTitle(kind, itemID); !ok {
+		return c.String(http.StatusNotFound, "Item not found")
+	}
+	days, err := strconv.Atoi(c.FormValue("days"))
+	if err != nil || !slices.Contains(previewDays, days) {
+		return c.String(http.StatusBadRequest, "Invalid link lifetime")
+	}
+
+	link, err := h.DB.CreatePreviewLink(
+		kind, itemID, time.Now().AddDate(0, 0, days),
+	)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create preview link",
+		)
+	}
+	rows, err := h.previewLinkRows(*link)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create preview link",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshPreviews")
+	return pages.PreviewLinkCreated(rows[0]).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleRevokePreview(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid preview link ID")
+	}
+
+	if err := h.DB.DeletePreviewLink(id); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to revoke preview link",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshPreviews")
+	return c.String(http.StatusOK, "")
+}
+
+// ── Experience ──────────────────────────────────────
+func (h *AdminHandler) HandleAdminExperience(c echo.Context) error {
+	experience, err := h.DB.GetAllExperiences()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load Experiences",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminExperienceContent(experience).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminExperiencePage(experience).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminExperienceForm(c echo.Context) error {
+	skills, err := h.DB.GetAllSkills()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load skills",
+		)
+	}
+
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.ExperienceForm(nil, skills).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid experience ID")
+	}
+	experience, err := h.DB.GetExperienceByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Experience not found")
+	}
+	return pages.ExperienceForm(experience, skills).
+		Render(c.Request().Context(), c.Response())
+}
+
+// experienceFromForm reads and validates the experience form. Highlights
+// are one per line, tags comma separated; skill_ids are the ticked
+// skills.
+func experienceFromForm(c echo.Context) (models.Experience, []int64, error) {
+	e := models.Experience{
+		Title:          strings.TrimSpace(c.FormValue("title")),
+		Company:        strings.TrimSpace(c.FormValue("company")),
+		Location:       strings.TrimSpace(c.FormValue("location")),
+		EmploymentType: c.FormValue("employment_type"),
+		Description:    strings.TrimSpace(c.FormValue("description")),
+	}
+	if e.Title == "" {
+		return e, nil, errors.New("Title required")
+	}
+	if e.Company == "" {
+		return e, nil, errors.New("Company required")
+	}
+	if !models.ValidEmploymentType(e.EmploymentType) {
+		return e, nil, errors.New("Invalid employment type")
+	}
+
+	var err error
+	if e.StartDate, err = models.ParseYearMonth(c.FormValue("start_date")); err != nil {
+		return e, nil, errors.New("Start date: " + err.Error())
+	}
+	if end := c.FormValue("end_date"); end != "" {
+		if e.EndDate, err = models.ParseYearMonth(end); err != nil {
+			return e, nil, errors.New("End date: " + err.Error())
+		}
+		if e.EndDate < e.StartDate {
+			return e, nil, errors.New("End date is before start date")
+		}
+	}
+
+	e.Highlights = formLines(c, "highlights")
+	e.Tags = models.SplitTags(c.FormValue("tags"))
+
+	skillIDs, err := formIDs(c, "skill_ids")
+	if err != nil {
+		return e, nil, errors.New("Invalid skill ID")
+	}
+	return e, skillIDs, nil
+}
+
+// formLines splits a textarea into its non-blank lines, dropping any
+// list markers ("-", "*", "•") typed in front.
+func formLines(c echo.Context, name string) []string {
+	var lines []string
+	for _, line := range strings.Split(c.FormValue(name), "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// formMonth reads an optional year-month field; label names it in the
+// error.
+func formMonth(c echo.Context, name, label string) (string, error) {
+	v := strings.TrimSpace(c.FormValue(name))
+	if v == "" {
+		return "", nil
+	}
+	month, err := models.ParseYearMonth(v)
+	if err != nil {
+		return "", errors.New(label + ": " + err.Error())
+	}
+	return month, nil
+}
+
+// formSectionOrder reads the section_<key> selects of a section order
+// picker: 0 hides a section, otherwise the numbers give the order, ties
+// falling back to the order of keys.
+func formSectionOrder(c echo.Context, keys []string) ([]string, error) {
+	position := make(map[string]int)
+	var sections []string
+	for _, key := range keys {
+		n, _ := strconv.Atoi(c.FormValue("section_" + key))
+		if n > 0 {
+			position[key] = n
+			sections = append(sections, key)
+		}
+	}
+	if len(sections) == 0 {
+		return nil, errors.New("Show at least one section")
+	}
+	slices.SortStableFunc(sections, func(a, b string) int {
+		return position[a] - position[b]
+	})
+	return sections, nil
+}
+
+// formIDs parses every value of a repeated ID field, such as a group of
+// checkboxes.
+func formIDs(c echo.Context, name string) ([]int64, error) {
+	form, err := c.FormParams()
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, v := range form[name] {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (h *AdminHandler) HandleCreateExperience(c echo.Context) error {
+	e, skillIDs, err := experienceFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateExperience(e, skillIDs); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to create experience",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshExperience")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdateExperience(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid experience ID")
+	}
+
+	e, skillIDs, err := experienceFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	e.ID = id
+
+	if err := h.DB.UpdateExperience(e, skillIDs); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to update Experience",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshExperience")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleDeleteExperience(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid experience ID")
+	}
+
+	err = h.DB.SoftDeleteExperience(id)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to delete project",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshExperience")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminExperienceTable(c echo.Context) error {
+	experience, err := h.DB.GetAllExperiences()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load experience",
+		)
+	}
+	return pages.ExperienceTable(experience).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Education ─────────────────────────────────────
+
+func (h *AdminHandler) HandleAdminEducation(c echo.Context) error {
+	education, err := h.DB.GetAllEducation()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load education",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminEducationContent(education).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminEducationPage(education).
 		Render(c.Request().Context(), c.Response())
 }
 
 func (h *AdminHandler) HandleAdminEducationForm(c echo.Context) error {
+	projects, err := h.DB.GetAllProjects()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load projects",
+		)
+	}
+
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.EducationForm(nil, projects).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid education ID")
+	}
+	education, err := h.DB.GetEducationByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Education not found")
+	}
+	return pages.EducationForm(education, projects).
+		Render(c.Request().Context(), c.Response())
+}
+
+// educationFromForm reads and validates the education form. Coursework
+// is one course per line; project_ids are the ticked class projects.
+func educationFromForm(c echo.Context) (models.Education, []int64, error) {
+	e := models.Education{
+		Degree:      strings.TrimSpace(c.FormValue("degree")),
+		College:     strings.TrimSpace(c.FormValue("college")),
+		Major:       strings.TrimSpace(c.FormValue("major")),
+		Minor:       strings.TrimSpace(c.FormValue("minor")),
+		Honors:      strings.TrimSpace(c.FormValue("honors")),
+		In_progress: c.FormValue("in_progress") == "true",
+		Coursework:  formLines(c, "coursework"),
+	}
+	if e.Degree == "" {
+		return e, nil, errors.New("Degree required")
+	}
+	if e.College == "" {
+		return e, nil, errors.New("College required")
+	}
+	if gpa := strings.TrimSpace(c.FormValue("gpa")); gpa != "" {
+		var err error
+		if e.Gpa, err = strconv.ParseFloat(gpa, 64); err != nil || e.Gpa < 0 {
+			return e, nil, errors.New("Invalid GPA")
+		}
+	}
+
+	var err error
+	if e.StartDate, err = formMonth(c, "start_date", "Start date"); err != nil {
+		return e, nil, err
+	}
+	if e.EndDate, err = formMonth(c, "end_date", "End date"); err != nil {
+		return e, nil, err
+	}
+	if e.StartDate != "" && e.EndDate != "" && e.EndDate < e.StartDate {
+		return e, nil, errors.New("End date is before start date")
+	}
+
+	projectIDs, err := formIDs(c, "project_ids")
+	if err != nil {
+		return e, nil, errors.New("Invalid project ID")
+	}
+	return e, projectIDs, nil
+}
+
+func (h *AdminHandler) HandleCreateEducation(c echo.Context) error {
+	e, projectIDs, err := educationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateEducation(e, projectIDs); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create education",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdateEducation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid education ID")
+	}
+
+	e, projectIDs, err := educationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	e.ID = id
+
+	if err := h.DB.UpdateEducation(e, projectIDs); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to update education",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleDeleteEducation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid education ID")
+	}
+
+	err = h.DB.SoftDeleteEducation(id)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to delete education",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminEducationTable(c echo.Context) error {
+	education, err := h.DB.GetAllEducation()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load education",
+		)
+	}
+	return pages.EducationTable(education).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Certifications ────────────────────────────────
+
+func (h *AdminHandler) HandleAdminCertifications(c echo.Context) error {
+	certs, err := h.DB.GetAllCertifications()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load certifications",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminCertificationsContent(certs).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminCertificationsPage(certs).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminCertificationForm(c echo.Context) error {
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.CertificationForm(nil).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid certification ID")
+	}
+	cert, err := h.DB.GetCertificationByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Certification not found")
+	}
+	return pages.CertificationForm(cert).
+		Render(c.Request().Context(), c.Response())
+}
+
+func certificationFromForm(c echo.Context) (models.Certification, error) {
+	cert := models.Certification{
+		Name:          strings.TrimSpace(c.FormValue("name")),
+		Issuer:        strings.TrimSpace(c.FormValue("issuer")),
+		CredentialID:  strings.TrimSpace(c.FormValue("credential_id")),
+		CredentialURL: strings.TrimSpace(c.FormValue("credential_url")),
+	}
+	if cert.Name == "" {
+		return cert, errors.New("Name required")
+	}
+	if cert.Issuer == "" {
+		return cert, errors.New("Issuer required")
+	}
+
+	var err error
+	if cert.IssuedDate, err = formMonth(c, "issued_date", "Issued"); err != nil {
+		return cert, err
+	}
+	if cert.IssuedDate == "" {
+		return cert, errors.New("Issue date required")
+	}
+	if cert.ExpiryDate, err = formMonth(c, "expiry_date", "Expires"); err != nil {
+		return cert, err
+	}
+	if cert.ExpiryDate != "" && cert.ExpiryDate < cert.IssuedDate {
+		return cert, errors.New("Expiry date is before issue date")
+	}
+	return cert, nil
+}
+
+func (h *AdminHandler) HandleCreateCertification(c echo.Context) error {
+	cert, err := certificationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateCertification(cert); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create certification",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshCertifications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdateCertification(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid certification ID")
+	}
+
+	cert, err := certificationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	cert.ID = id
+
+	if err := h.DB.UpdateCertification(cert); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to update certification",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshCertifications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleDeleteCertification(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid certification ID")
+	}
+
+	if err := h.DB.SoftDeleteCertification(id); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to delete certification",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshCertifications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminCertificationsTable(c echo.Context) error {
+	certs, err := h.DB.GetAllCertifications()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load certifications",
+		)
+	}
+	return pages.CertificationsTable(certs).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Awards ────────────────────────────────────────
+
+func (h *AdminHandler) HandleAdminAwards(c echo.Context) error {
+	awards, err := h.DB.GetAllAwards()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load awards",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminAwardsContent(awards).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminAwardsPage(awards).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminAwardForm(c echo.Context) error {
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.AwardForm(nil).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid award ID")
+	}
+	award, err := h.DB.GetAwardByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Award not found")
+	}
+	return pages.AwardForm(award).
+		Render(c.Request().Context(), c.Response())
+}
+
+func awardFromForm(c echo.Context) (models.Award, error) {
+	a := models.Award{
+		Title:       strings.TrimSpace(c.FormValue("title")),
+		Issuer:      strings.TrimSpace(c.FormValue("issuer")),
+		Description: strings.TrimSpace(c.FormValue("description")),
+	}
+	if a.Title == "" {
+		return a, errors.New("Title required")
+	}
+
+	var err error
+	if a.Date, err = formMonth(c, "date", "Date"); err != nil {
+		return a, err
+	}
+	if a.Date == "" {
+		return a, errors.New("Date required")
+	}
+	return a, nil
+}
+
+func (h *AdminHandler) HandleCreateAward(c echo.Context) error {
+	a, err := awardFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateAward(a); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create award",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshAwards")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdateAward(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid award ID")
+	}
+
+	a, err := awardFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	a.ID = id
+
+	if err := h.DB.UpdateAward(a); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to update award",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshAwards")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleDeleteAward(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid award ID")
+	}
+
+	if err := h.DB.SoftDeleteAward(id); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to delete award",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshAwards")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminAwardsTable(c echo.Context) error {
+	awards, err := h.DB.GetAllAwards()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load awards",
+		)
+	}
+	return pages.AwardsTable(awards).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Publications ──────────────────────────────────
+
+func (h *AdminHandler) HandleAdminPublications(c echo.Context) error {
+	pubs, err := h.DB.GetAllPublications()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load publications",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminPublicationsContent(pubs).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminPublicationsPage(pubs).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminPublicationForm(c echo.Context) error {
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.PublicationForm(nil).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid publication ID")
+	}
+	pub, err := h.DB.GetPublicationByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Publication not found")
+	}
+	return pages.PublicationForm(pub).
+		Render(c.Request().Context(), c.Response())
+}
+
+func publicationFromForm(c echo.Context) (models.Publication, error) {
+	p := models.Publication{
+		Title:       strings.TrimSpace(c.FormValue("title")),
+		Kind:        c.FormValue("kind"),
+		Venue:       strings.TrimSpace(c.FormValue("venue")),
+		URL:         strings.TrimSpace(c.FormValue("url")),
+		Description: strings.TrimSpace(c.FormValue("description")),
+	}
+	if p.Title == "" {
+		return p, errors.New("Title required")
+	}
+	if !models.ValidPublicationKind(p.Kind) {
+		return p, errors.New("Invalid kind")
+	}
+
+	var err error
+	if p.Date, err = formMonth(c, "date", "Date"); err != nil {
+		return p, err
+	}
+	if p.Date == "" {
+		return p, errors.New("Date required")
+	}
+	return p, nil
+}
+
+func (h *AdminHandler) HandleCreatePublication(c echo.Context) error {
+	p, err := publicationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreatePublication(p); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create publication",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshPublications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdatePublication(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid publication ID")
+	}
+
+	p, err := publicationFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	p.ID = id
+
+	if err := h.DB.UpdatePublication(p); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to update publication",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshPublications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleDeletePublication(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid publication ID")
+	}
+
+	if err := h.DB.SoftDeletePublication(id); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to delete publication",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshPublications")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminPublicationsTable(c echo.Context) error {
+	pubs, err := h.DB.GetAllPublications()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load publications",
+		)
+	}
+	return pages.PublicationsTable(pubs).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Resume ────────────────────────────────────────
+
+// resumeProjectChoices splits the projects into those chosen for the
+// resume, in order, and the non-private rest that could be added.
+func (h *AdminHandler) resumeProjectChoices() (chosen, available []models.Project, err error) {
+	chosen, err = h.DB.GetResumeProjects()
+	if err != nil {
+		return nil, nil, err
+	}
+	all, err := h.DB.GetAllProjects()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	picked := make(map[int64]bool, len(chosen))
+	for _, p := range chosen {
+		picked[p.ID] = true
+	}
+	for _, p := range all {
+		if !picked[p.ID] && p.Visibility != models.VisibilityPrivate {
+			available = append(available, p)
+		}
+	}
+	return chosen, available, nil
+}
+
+func (h *AdminHandler) renderResumeProjects(c echo.Context) error {
+	chosen, available, err := h.resumeProjectChoices()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load projects",
+		)
+	}
+	return pages.ResumeProjectsEditor(chosen, available).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminResume(c echo.Context) error {
+	chosen, available, err := h.resumeProjectChoices()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load projects",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminResumeContent(chosen, available).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminResumePage(chosen, available).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAddResumeProject(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.FormValue("project_id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid project ID")
+	}
+	if _, err := h.DB.GetProjectByID(projectID); err != nil {
+		return c.String(http.StatusNotFound, "Project not found")
+	}
+
+	if err := h.DB.AddResumeProject(projectID); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to add project",
+		)
+	}
+	return h.renderResumeProjects(c)
+}
+
+func (h *AdminHandler) HandleMoveResumeProject(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	delta := 1
+	if c.QueryParam("dir") == "up" {
+		delta = -1
+	}
+	if err := h.DB.MoveResumeProject(projectID, delta); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to move project",
+		)
+	}
+	return h.renderResumeProjects(c)
+}
+
+func (h *AdminHandler) HandleRemoveResumeProject(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	if err := h.DB.RemoveResumeProject(projectID); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to remove project",
+		)
+	}
+	return h.renderResumeProjects(c)
+}
+
+// maxImportSize caps an uploaded JSON Resume file.
+const maxImportSize = 1 << 20
+
+// HandleImportPreview reads an uploaded JSON Resume and shows what
+// importing it would change, without writing anything.
+func (h *AdminHandler) HandleImportPreview(c echo.Context) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Choose a file to import")
+	}
+	if fh.Size > maxImportSize {
+		return c.String(http.StatusBadRequest, "File is too large")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Failed to read file")
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxImportSize))
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Failed to read file")
+	}
+
+	r, err := parseJSONResume(data)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid JSON Resume: "+err.Error())
+	}
+	steps, err := planImport(h.DB, r)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to compare with current data",
+		)
+	}
+
+	changes := make([]pages.ImportChange, len(steps))
+	for i, s := range steps {
+		changes[i] = s.ImportChange
+	}
+	return pages.ImportPreview(changes, string(data)).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleImport applies a previewed JSON Resume. The plan is worked out
+// again against the current data, so edits made since the preview are
+// not clobbered with stale values.
+func (h *AdminHandler) HandleImport(c echo.Context) error {
+	r, err := parseJSONResume([]byte(c.FormValue("payload")))
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid JSON Resume: "+err.Error())
+	}
+	steps, err := planImport(h.DB, r)
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to compare with current data",
+		)
+	}
+
+	// All or nothing: a failed step rolls back the ones before it.
+	created, updated := 0, 0
+	var failed *importStep
+	err = h.DB.WithTx(func(tx *db.DB) error {
+		for i, s := range steps {
+			if s.Apply == nil {
+				continue
+			}
+			if err := s.Apply(tx); err != nil {
+				failed = &steps[i]
+				return err
+			}
+			if s.Action == pages.ImportCreate {
+				created++
+			} else {
+				updated++
+			}
+		}
+		return nil
+	})
+	if failed != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			fmt.Sprintf(
+				"Failed to import %s %q; nothing was imported",
+				strings.ToLower(failed.Kind), failed.Title,
+			),
+		)
+	}
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to import")
+	}
+	return pages.ImportResult(created, updated).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Sections ──────────────────────────────────────
+
+// sectionLayouts loads the section order of every surface, and the
+// custom sections that can be placed on them.
+func (h *AdminHandler) sectionLayouts() ([]pages.SectionLayout, []models.CustomSection, error) {
+	custom, err := h.DB.GetAllCustomSections()
+	if err != nil {
+		return nil, nil, err
+	}
+	var layouts []pages.SectionLayout
+	for _, surface := range models.Surfaces {
+		sections, err := h.DB.GetSectionOrder(surface)
+		if err != nil {
+			return nil, nil, err
+		}
+		layouts = append(layouts, pages.SectionLayout{
+			Surface: surface, Sections: sections,
+		})
+	}
+	return layouts, custom, nil
+}
+
+func (h *AdminHandler) HandleAdminSections(c echo.Context) error {
+	layouts, custom, err := h.sectionLayouts()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load sections",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminSectionsContent(layouts, custom).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminSectionsPage(layouts, custom).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleAdminSectionLayouts(c echo.Context) error {
+	layouts, custom, err := h.sectionLayouts()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load sections",
+		)
+	}
+	return pages.SectionLayouts(layouts, custom).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleUpdateSectionLayout saves the section order of one surface.
+func (h *AdminHandler) HandleUpdateSectionLayout(c echo.Context) error {
+	surface := c.Param("surface")
+	if !slices.Contains(models.Surfaces, surface) {
+		return c.String(http.StatusNotFound, "Unknown page")
+	}
+	custom, err := h.DB.GetAllCustomSections()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load sections",
+		)
+	}
+
+	keys := slices.Clone(models.ResumeSections)
+	for _, cs := range custom {
+		keys = append(keys, cs.Key())
+	}
+	sections, err := formSectionOrder(c, keys)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.DB.SetSectionOrder(surface, sections); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to save sections",
+		)
+	}
+	return c.String(http.StatusOK, "Saved")
+}
+
+func (h *AdminHandler) HandleAdminCustomSectionForm(c echo.Context) error {
 	idStr := c.Param("id")
 	if idStr == "" {
-		return pages.EducationForm(nil).
+		return pages.CustomSectionForm(nil).
 			Render(c.Request().Context(), c.Response())
 	}
 
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid education ID")
+		return c.String(http.StatusBadRequest, "Invalid section ID")
 	}
-	education, err := h.DB.GetEducationByID(id)
+	section, err := h.DB.GetCustomSectionByID(id)
 	if err != nil {
-		return c.String(http.StatusNotFound, "Education not found")
+		return c.String(http.StatusNotFound, "Section not found")
 	}
-	return pages.EducationForm(education).
+	return pages.CustomSectionForm(section).
 		Render(c.Request().Context(), c.Response())
 }
 
-func (h *AdminHandler) HandleCreateEducation(c echo.Context) error {
-	degree := c.FormValue("degree")
-	college := c.FormValue("college")
-	gpa, _ := strconv.ParseFloat(c.FormValue("gpa"), 64)
-	inProgress := c.FormValue("in_progress") == "true"
+func customSectionFromForm(c echo.Context) (models.CustomSection, error) {
+	s := models.CustomSection{
+		Title: strings.TrimSpace(c.FormValue("title")),
+		Body:  strings.TrimSpace(c.FormValue("body")),
+	}
+	if s.Title == "" {
+		return s, errors.New("Title required")
+	}
+	return s, nil
+}
 
-	if degree == "" {
-		return c.String(http.StatusBadRequest, "Degree required")
+func (h *AdminHandler) HandleCreateCustomSection(c echo.Context) error {
+	s, err := customSectionFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateCustomSection(s); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create section",
+		)
 	}
-	if college == "" {
-		return c.String(http.StatusBadRequest, "College required")
+
+	c.Response().Header().Set("HX-Trigger", "refreshSections")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleUpdateCustomSection(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid section ID")
 	}
 
-	err := h.DB.CreateEducation(degree, college, gpa, inProgress)
+	s, err := customSectionFromForm(c)
 	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	s.ID = id
+
+	if err := h.DB.UpdateCustomSection(s); err != nil {
 		return c.String(
 			http.StatusInternalServerError,
-			"Failed to create education",
+			"Failed to update section",
 		)
 	}
 
-	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	c.Response().Header().Set("HX-Trigger", "refreshSections")
 	return c.String(http.StatusOK, "")
 }
 
-func (h *AdminHandler) HandleUpdateEducation(c echo.Context) error {
+func (h *AdminHandler) HandleDeleteCustomSection(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid education ID")
+		return c.String(http.StatusBadRequest, "Invalid section ID")
+	}
+
+	if err := h.DB.SoftDeleteCustomSection(id); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to delete section",
+		)
 	}
 
-	degree := c.FormValue("degree")
-	college := c.FormValue("college")
-	gpa, _ := strconv.ParseFloat(c.FormValue("gpa"), 64)
-	inProgress := c.FormValue("in_progress") == "true"
+	c.Response().Header().Set("HX-Trigger", "refreshSections")
+	return c.String(http.StatusOK, "")
+}
 
-	err = h.DB.UpdateEducation(id, degree, college, gpa, inProgress)
+func (h *AdminHandler) HandleAdminCustomSectionsTable(c echo.Context) error {
+	custom, err := h.DB.GetAllCustomSections()
 	if err != nil {
 		return c.String(
 			http.StatusInternalServerError,
-			"Failed to update education",
+			"Failed to load sections",
+		)
+	}
+	return pages.CustomSectionsTable(custom).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Resume Variants ───────────────────────────────
+
+func (h *AdminHandler) HandleAdminVariants(c echo.Context) error {
+	variants, err := h.DB.GetResumeVariants()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load variants",
 		)
 	}
 
-	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminVariantsContent(variants).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminVariantsPage(variants).
+		Render(c.Request().Context(), c.Response())
+}
+
+// variantPool loads everything a variant may pick from: all experience
+// and education, and the skills and projects that are not private.
+func (h *AdminHandler) variantPool() (pages.VariantPool, error) {
+	var pool pages.VariantPool
+	var err error
+	if pool.Experiences, err = h.DB.GetAllExperiences(); err != nil {
+		return pool, err
+	}
+	if pool.Education, err = h.DB.GetAllEducation(); err != nil {
+		return pool, err
+	}
+	skills, err := h.DB.GetAllSkills()
+	if err != nil {
+		return pool, err
+	}
+	pool.Skills = notPrivateSkills(skills)
+	projects, err := h.DB.GetAllProjects()
+	if err != nil {
+		return pool, err
+	}
+	pool.Projects = notPrivateProjects(projects)
+	return pool, nil
+}
+
+func (h *AdminHandler) HandleAdminVariantForm(c echo.Context) error {
+	pool, err := h.variantPool()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load resume content",
+		)
+	}
+
+	idStr := c.Param("id")
+	if idStr == "" {
+		return pages.VariantForm(nil, pool).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid variant ID")
+	}
+	variant, err := h.DB.GetResumeVariantByID(id)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Variant not found")
+	}
+	return pages.VariantForm(variant, pool).
+		Render(c.Request().Context(), c.Response())
+}
+
+// variantFromForm reads the variant form.
+func variantFromForm(c echo.Context) (models.ResumeVariant, error) {
+	v := models.ResumeVariant{
+		Name:     strings.TrimSpace(c.FormValue("name")),
+		Slug:     strings.TrimSpace(c.FormValue("slug")),
+		Headline: strings.TrimSpace(c.FormValue("headline")),
+		Summary:  strings.TrimSpace(c.FormValue("summary")),
+	}
+	if v.Name == "" {
+		return v, errors.New("Name is required")
+	}
+
+	sections, err := formSectionOrder(c, models.ResumeSections)
+	if err != nil {
+		return v, err
+	}
+	v.Sections = sections
+
+	form, err := c.FormParams()
+	if err != nil {
+		return v, errors.New("Invalid form")
+	}
+	for field, ids := range map[string]*[]int64{
+		"experience_ids": &v.ExperienceIDs,
+		"skill_ids":      &v.SkillIDs,
+		"project_ids":    &v.ProjectIDs,
+		"education_ids":  &v.EducationIDs,
+	} {
+		for _, s := range form[field] {
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return v, errors.New("Invalid item ID")
+			}
+			*ids = append(*ids, id)
+		}
+	}
+	return v, nil
+}
+
+func (h *AdminHandler) HandleCreateVariant(c echo.Context) error {
+	v, err := variantFromForm(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := h.DB.CreateResumeVariant(v); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to create variant",
+		)
+	}
+
+	c.Response().Header().Set("HX-Trigger", "refreshVariants")
 	return c.String(http.StatusOK, "")
 }
 
-func (h *AdminHandler) HandleDeleteEducation(c echo.Context) error {
+func (h *AdminHandler) HandleUpdateVariant(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "Invalid education ID")
+		return c.String(http.StatusBadRequest, "Invalid variant ID")
 	}
 
-	err = h.DB.SoftDeleteEducation(id)
+	v, err := variantFromForm(c)
 	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	v.ID = id
+
+	if err := h.DB.UpdateResumeVariant(v); err != nil {
 		return c.String(
 			http.StatusInternalServerError,
-			"Failed to delete education",
+			"Failed to update variant",
 		)
 	}
 
-	c.Response().Header().Set("HX-Trigger", "refreshEducation")
+	c.Response().Header().Set("HX-Trigger", "refreshVariants")
 	return c.String(http.StatusOK, "")
 }
 
-func (h *AdminHandler) HandleAdminEducationTable(c echo.Context) error {
-	education, err := h.DB.GetAllEducation()
+func (h *AdminHandler) HandleDeleteVariant(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid variant ID")
+	}
+
+	if err := h.DB.DeleteResumeVariant(id); err != nil {
 		return c.String(
 			http.StatusInternalServerError,
-			"Failed to load education",
+			"Failed to delete variant",
 		)
 	}
-	return pages.EducationTable(education).
+
+	c.Response().Header().Set("HX-Trigger", "refreshVariants")
+	return c.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) HandleAdminVariantsTable(c echo.Context) error {
+	variants, err := h.DB.GetResumeVariants()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load variants",
+		)
+	}
+	return pages.VariantsTable(variants).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Job Match ─────────────────────────────────────
+
+func (h *AdminHandler) HandleAdminJobMatch(c echo.Context) error {
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminJobMatchContent().
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminJobMatchPage().
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleJobMatch compares a pasted job description with the skills that
+// may appear on a resume, backing each match with its projects.
+func (h *AdminHandler) HandleJobMatch(c echo.Context) error {
+	posting := c.FormValue("description")
+	if strings.TrimSpace(posting) == "" {
+		return c.String(http.StatusBadRequest, "Paste a job description")
+	}
+	if len(posting) > maxPostingSize {
+		return c.String(http.StatusBadRequest, "Job description is too long")
+	}
+
+	skills, err := h.DB.GetAllSkillsWithAliases()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load skills",
+		)
+	}
+	skills = notPrivateSkills(skills)
+	if err := h.DB.LoadSkillMetrics(skills); err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load skill metrics",
+		)
+	}
+
+	match := models.JobMatch{Title: strings.TrimSpace(c.FormValue("title"))}
+	match.Matched, match.Missing = matchPosting(posting, skills)
+	for i, sm := range match.Matched {
+		projects, err := h.DB.GetProjectsForSkill(sm.Skill.ID)
+		if err != nil {
+			return c.String(
+				http.StatusInternalServerError, "Failed to load projects",
+			)
+		}
+		match.Matched[i].Projects = notPrivateProjects(projects)
+	}
+
+	experiences, err := h.DB.GetAllExperiences()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to load experience",
+		)
+	}
+	matchedIDs := match.SkillIDs()
+	for _, e := range experiences {
+		if slices.ContainsFunc(e.Skills, func(s models.Skill) bool {
+			return slices.Contains(matchedIDs, s.ID)
+		}) {
+			match.Experiences = append(match.Experiences, e.ID)
+		}
+	}
+
+	return pages.JobMatchResult(match).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleJobMatchVariant opens the variant form prefilled from a match:
+// the matched skills, their projects and the experience that used them.
+// Nothing is saved until the form is submitted.
+func (h *AdminHandler) HandleJobMatchVariant(c echo.Context) error {
+	pool, err := h.variantPool()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load resume content",
+		)
+	}
+
+	draft := models.ResumeVariant{Name: strings.TrimSpace(c.FormValue("title"))}
+	if draft.Name == "" {
+		draft.Name = "Job match"
+	}
+	for field, ids := range map[string]*[]int64{
+		"experience_ids": &draft.ExperienceIDs,
+		"skill_ids":      &draft.SkillIDs,
+		"project_ids":    &draft.ProjectIDs,
+	} {
+		if *ids, err = formIDs(c, field); err != nil {
+			return c.String(http.StatusBadRequest, "Invalid item ID")
+		}
+	}
+	return pages.VariantForm(&draft, pool).
+		Render(c.Request().Context(), c.Response())
+}
+
+// ── Profile ───────────────────────────────────────
+
+func (h *AdminHandler) HandleAdminProfile(c echo.Context) error {
+	profile, err := h.DB.GetProfile()
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to load profile",
+		)
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminProfileContent(profile).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminProfilePage(profile).
 		Render(c.Request().Context(), c.Response())
 }
+
+func (h *AdminHandler) HandleUpdateProfile(c echo.Context) error {
+	profile := models.Profile{
+		Name:        strings.TrimSpace(c.FormValue("name")),
+		Headline:    strings.TrimSpace(c.FormValue("headline")),
+		Email:       strings.TrimSpace(c.FormValue("email")),
+		Phone:       strings.TrimSpace(c.FormValue("phone")),
+		Location:    strings.TrimSpace(c.FormValue("location")),
+		LinkedInURL: strings.TrimSpace(c.FormValue("linkedin_url")),
+		GitHubURL:   strings.TrimSpace(c.FormValue("github_url")),
+		WebsiteURL:  strings.TrimSpace(c.FormValue("website_url")),
+		Summary:     strings.TrimSpace(c.FormValue("summary")),
+	}
+	if profile.Name == "" {
+		return c.String(http.StatusBadRequest, "Name is required")
+	}
+
+	if err := h.DB.UpdateProfile(profile); err != nil {
+		return c.String(
+			http.StatusInternalServerError,
+			"Failed to update profile",
+		)
+	}
+
+	return c.String(http.StatusOK, "Saved")
+}
+
+// ── Settings ──────────────────────────────────────
+
+func (h *AdminHandler) HandleAdminSettings(c echo.Context) error {
+	themes := resumeThemeOptions(h.DB)
+	theme := configuredResumeTheme(h.DB)
+	fontOpts := fontOptions()
+	skillOrder := configuredSkillOrder(h.DB)
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.AdminSettingsContent(themes, theme, fontOpts, skillOrder).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.AdminSettingsPage(themes, theme, fontOpts, skillOrder).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *AdminHandler) HandleUpdateSettings(c echo.Context) error {
+	theme := c.FormValue("resume_theme")
+	if _, ok := resumeTheme(theme); !ok {
+		return c.String(http.StatusBadRequest, "Unknown theme")
+	}
+
+	skillOrder := c.FormValue("skill_order")
+	if !slices.Contains(models.SkillOrders, skillOrder) {
+		return c.String(http.StatusBadRequest, "Unknown skill order")
+	}
+
+	settings := map[string]string{
+		db.SettingResumeTheme:      theme,
+		db.SettingResumeSkillOrder: skillOrder,
+	}
+	for _, t := range resumeThemes {
+		font := c.FormValue("font_" + t.Name())
+		if font == "" {
+			continue
+		}
+		if _, ok := fonts.Lookup(font); !ok {
+			return c.String(http.StatusBadRequest, "Unknown font")
+		}
+		settings[db.SettingResumeFontPrefix+t.Name()] = font
+	}
+
+	for key, value := range settings {
+		if err := h.DB.SetSetting(key, value); err != nil {
+			return c.String(
+				http.StatusInternalServerError,
+				"Failed to save settings",
+			)
+		}
+	}
+
+	return c.String(http.StatusOK, "Saved")
+}
@@ -0,0 +1,42 @@
+// handlers/blog.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/labstack/echo/v4"
+)
+
+type BlogHandler struct {
+	DB *db.DB
+}
+
+func (h *BlogHandler) HandleBlogPage(c echo.Context) error {
+	posts, err := h.DB.GetPublishedPosts()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load posts")
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.BlogContent(posts).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.BlogPage(posts).
+		Render(c.Request().Context(), c.Response())
+}
+
+func (h *BlogHandler) HandleBlogPost(c echo.Context) error {
+	post, err := h.DB.GetPostBySlug(c.Param("slug"))
+	if err != nil {
+		return c.String(http.StatusNotFound, "Post not found")
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.BlogPostContent(post).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.BlogPostPage(post).
+		Render(c.Request().Context(), c.Response())
+}
@@ -0,0 +1,131 @@
+// handlers/graph.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/labstack/echo/v4"
+)
+
+type GraphHandler struct {
+	DB *db.DB
+}
+
+func (h *GraphHandler) HandleGraphPage(c echo.Context) error {
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.GraphContent().
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.GraphPage().
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleGraphJSON serves the graph of listed skills, the listed projects
+// that use them and their categories.
+func (h *GraphHandler) HandleGraphJSON(c echo.Context) error {
+	skills, err := h.DB.GetListedSkills()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load skills")
+	}
+	projects, err := h.DB.GetListedProjects()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load projects")
+	}
+	categories, err := h.DB.GetAllSkillCategories()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load categories")
+	}
+	uses, err := h.DB.GetAllSkillUses()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load skill uses")
+	}
+	return c.JSON(http.StatusOK, buildGraph(skills, projects, categories, uses))
+}
+
+// buildGraph links each skill to its category and to the projects that
+// use it, and links skills used together by how many projects they
+// share. Projects and categories with no listed skills are left out.
+func buildGraph(
+	skills []models.Skill, projects []models.Project,
+	categories []models.Skill_category, uses []models.SkillUse,
+) models.Graph {
+	g := models.Graph{Nodes: []models.GraphNode{}, Edges: []models.GraphEdge{}}
+	nodes := map[string]int{} // node ID -> index in g.Nodes
+	addNode := func(n models.GraphNode) {
+		if _, ok := nodes[n.ID]; !ok {
+			nodes[n.ID] = len(g.Nodes)
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+	addEdge := func(source, target string, weight int) {
+		g.Edges = append(g.Edges, models.GraphEdge{
+			Source: source, Target: target, Weight: weight,
+		})
+		g.Nodes[nodes[source]].Weight++
+		g.Nodes[nodes[target]].Weight++
+	}
+
+	categoryIDs := map[string]int64{}
+	for _, cat := range categories {
+		categoryIDs[cat.Name] = cat.ID
+	}
+	skillIDs := map[int64]string{}
+	for _, s := range skills {
+		id := fmt.Sprintf("skill-%d", s.ID)
+		skillIDs[s.ID] = id
+		addNode(models.GraphNode{
+			ID: id, Kind: models.GraphSkill, Label: s.Name,
+			URL: fmt.Sprintf("/api/skills/%d", s.ID),
+		})
+		catID := fmt.Sprintf("category-%d", categoryIDs[s.Category])
+		addNode(models.GraphNode{
+			ID: catID, Kind: models.GraphCategory, Label: s.Category,
+		})
+		addEdge(id, catID, 1)
+	}
+
+	listedProjects := map[int64]models.Project{}
+	for _, p := range projects {
+		listedProjects[p.ID] = p
+	}
+	skillsByProject := map[int64][]int64{} // project ID -> its listed skill IDs
+	for _, u := range uses {
+		skillID, ok := skillIDs[u.Skill_ID]
+		p, listed := listedProjects[u.Project_ID]
+		if !ok || !listed {
+			continue
+		}
+		id := fmt.Sprintf("project-%d", p.ID)
+		addNode(models.GraphNode{
+			ID: id, Kind: models.GraphProject, Label: p.Title,
+			URL: fmt.Sprintf("/api/projects/%d/collapse", p.ID),
+		})
+		addEdge(skillID, id, 1)
+		skillsByProject[p.ID] = append(skillsByProject[p.ID], u.Skill_ID)
+	}
+
+	// Skills used together, weighted by shared projects. Pairs are
+	// walked in project order so the output is stable.
+	shared := map[[2]int64]int{}
+	var pairs [][2]int64
+	for _, p := range projects {
+		ids := skillsByProject[p.ID]
+		for i := range ids {
+			for j := i + 1; j < len(ids); j++ {
+				pair := [2]int64{min(ids[i], ids[j]), max(ids[i], ids[j])}
+				if shared[pair] == 0 {
+					pairs = append(pairs, pair)
+				}
+				shared[pair]++
+			}
+		}
+	}
+	for _, pair := range pairs {
+		addEdge(skillIDs[pair[0]], skillIDs[pair[1]], shared[pair])
+	}
+	return g
+}
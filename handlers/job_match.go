@@ -0,0 +1,171 @@
+// handlers/job_match.go
+package handlers
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// maxPostingSize caps a pasted job description.
+const maxPostingSize = 64 << 10
+
+// jobKeywords are skills job postings commonly ask for. A keyword found
+// in a posting that matches none of our skills is reported as missing;
+// words outside this list are never reported, so prose doesn't show up
+// as gaps.
+var jobKeywords = []string{
+	// Languages
+	"Go", "Python", "Java", "JavaScript", "TypeScript", "C", "C++", "C#",
+	"Rust", "Ruby", "PHP", "Kotlin", "Swift", "Scala", "Lua", "R", "SQL",
+	"Bash", "HTML", "CSS", "Dart", "Elixir", "Haskell", "Perl",
+	// Frameworks and libraries
+	"React", "Vue", "Angular", "Svelte", "Next.js", "Node.js", "Express",
+	"Django", "Flask", "FastAPI", "Spring", "Rails", "Laravel", ".NET",
+	"Tailwind", "HTMX", "GraphQL", "gRPC", "REST", "jQuery", "Unity",
+	"TensorFlow", "PyTorch", "Pandas", "NumPy",
+	// Data stores
+	"PostgreSQL", "MySQL", "SQLite", "MongoDB", "Redis", "Elasticsearch",
+	"Kafka", "RabbitMQ", "DynamoDB", "Cassandra", "Snowflake",
+	// Infrastructure and tools
+	"Docker", "Kubernetes", "Terraform", "Ansible", "AWS", "GCP", "Azure",
+	"Linux", "Git", "GitHub Actions", "Jenkins", "CI/CD", "Nginx",
+	"Prometheus", "Grafana", "Microservices", "Serverless",
+	// Practices
+	"Agile", "Scrum", "TDD", "Unit Testing", "Machine Learning",
+	"Data Analysis", "Distributed Systems", "System Design",
+}
+
+// keywordSpellings are other ways postings write some jobKeywords. They
+// only serve to spot keywords none of the skills cover: the skills
+// themselves match by their own name and aliases, which are kept in the
+// database and edited with the skill.
+var keywordSpellings = map[string][]string{
+	"Go":               {"Golang"},
+	"JavaScript":       {"JS", "ECMAScript"},
+	"TypeScript":       {"TS"},
+	"Python":           {"Py"},
+	"C++":              {"CPP"},
+	"C#":               {"CSharp"},
+	"Node.js":          {"Node"},
+	"React":            {"ReactJS"},
+	"Vue":              {"VueJS"},
+	"PostgreSQL":       {"Postgres", "psql"},
+	"MongoDB":          {"Mongo"},
+	"Kubernetes":       {"K8s"},
+	"AWS":              {"Amazon Web Services"},
+	"GCP":              {"Google Cloud", "Google Cloud Platform"},
+	"Azure":            {"Microsoft Azure"},
+	"Machine Learning": {"ML"},
+	"CI/CD":            {"CICD", "Continuous Integration"},
+	"REST":             {"RESTful", "REST API", "REST APIs"},
+	"Bash":             {"Shell"},
+	"Unit Testing":     {"Unit Tests"},
+	".NET":             {".NET Core"},
+}
+
+// postingToken matches a word of a posting, keeping the punctuation of
+// names like C++, C#, CI/CD, Node.js and .NET.
+var postingToken = regexp.MustCompile(`\.?[\pL\pN][\pL\pN+#./]*`)
+
+// maxPhraseWords is the longest phrase, in words, looked up as a term.
+const maxPhraseWords = 3
+
+// postingTerms finds every phrase of up to maxPhraseWords words in the
+// posting and maps its normalized form to how it was written, preferring
+// a capitalized spelling.
+func postingTerms(posting string) map[string]string {
+	var words []string
+	for _, w := range postingToken.FindAllString(posting, -1) {
+		words = append(words, strings.TrimRight(w, "./"))
+	}
+
+	terms := make(map[string]string)
+	for i := range words {
+		for n := 1; n <= maxPhraseWords && i+n <= len(words); n++ {
+			phrase := strings.Join(words[i:i+n], " ")
+			key := models.NormalizeSkillName(phrase)
+			if key == "" {
+				continue
+			}
+			if prev, seen := terms[key]; !seen || !capitalized(prev) && capitalized(phrase) {
+				terms[key] = phrase
+			}
+		}
+	}
+	return terms
+}
+
+func capitalized(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// plainWords are skill names that are also ordinary English words.
+var plainWords = map[string]bool{
+	"rust": true, "swift": true, "express": true, "spring": true,
+	"rails": true, "unity": true, "rest": true, "dart": true,
+	"pandas": true, "flask": true, "agile": true, "shell": true,
+}
+
+// termInPosting looks up a normalized term. Words of one or two letters,
+// like Go, C or R, and plainWords only count when the posting
+// capitalizes them.
+func termInPosting(terms map[string]string, key string) (string, bool) {
+	written, ok := terms[key]
+	if !ok {
+		return "", false
+	}
+	plain := len([]rune(written)) <= 2 || plainWords[strings.ToLower(written)]
+	if plain && !capitalized(written) {
+		return "", false
+	}
+	return written, true
+}
+
+// matchPosting checks skills, by name or alias, against a job posting.
+// It returns the skills the posting mentions, in the given order, and the known
+// keywords it mentions that none of the skills cover.
+func matchPosting(posting string, skills []models.Skill) ([]models.SkillMatch, []string) {
+	terms := postingTerms(posting)
+
+	var matched []models.SkillMatch
+	covered := make(map[string]bool)
+	for _, s := range skills {
+		found := false
+		for _, name := range append([]string{s.Name}, s.Aliases...) {
+			key := models.NormalizeSkillName(name)
+			covered[key] = true
+			if found {
+				continue
+			}
+			if written, ok := termInPosting(terms, key); ok {
+				matched = append(matched, models.SkillMatch{Skill: s, Term: written})
+				found = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, kw := range jobKeywords {
+		keys := []string{models.NormalizeSkillName(kw)}
+		for _, spelling := range keywordSpellings[kw] {
+			keys = append(keys, models.NormalizeSkillName(spelling))
+		}
+		if slices.ContainsFunc(keys, func(key string) bool { return covered[key] }) {
+			continue
+		}
+		if slices.ContainsFunc(keys, func(key string) bool {
+			_, ok := termInPosting(terms, key)
+			return ok
+		}) {
+			missing = append(missing, kw)
+		}
+	}
+	return matched, missing
+}
@@ -0,0 +1,76 @@
+// handlers/post_scheduler.go
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DYankee/resume2/db"
+)
+
+// ── Post scheduler ────────────────────────────────
+//
+// Public post queries compare publish_at and unpublish_at with the
+// current time, so a scheduled post appears and an expired one goes away
+// on its own. The scheduler wakes at those moments to unpublish expired
+// posts, so the admin sees them as drafts again.
+
+// maxSchedulerSleep bounds how long the scheduler waits between checks,
+// in case a post was changed without a call to Reschedule.
+const maxSchedulerSleep = time.Hour
+
+// PostScheduler flips posts at their scheduled times. The zero value is
+// not usable; create one with NewPostScheduler.
+type PostScheduler struct {
+	DB   *db.DB
+	wake chan struct{}
+}
+
+func NewPostScheduler(database *db.DB) *PostScheduler {
+	return &PostScheduler{
+		DB:   database,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Reschedule makes Run look again for the next scheduled change, after a
+// post's schedule was edited. It is safe on a nil scheduler.
+func (s *PostScheduler) Reschedule() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default: // a wake-up is already pending
+	}
+}
+
+// Run expires posts and sleeps until the next scheduled change, until
+// ctx is done.
+func (s *PostScheduler) Run(ctx context.Context) {
+	for {
+		if _, err := s.DB.ExpirePosts(); err != nil {
+			log.Printf("post scheduler: %v", err)
+		}
+
+		sleep := maxSchedulerSleep
+		next, ok, err := s.DB.NextPostChange()
+		if err != nil {
+			log.Printf("post scheduler: %v", err)
+		} else if ok {
+			// A second late, so the change is due when we check.
+			sleep = min(sleep, time.Until(next)+time.Second)
+		}
+
+		timer := time.NewTimer(sleep)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// handlers/preview.go
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/labstack/echo/v4"
+)
+
+// ── Preview links ─────────────────────────────────
+//
+// A preview URL is /preview/<link ID>.<signature>. The signature is an
+// HMAC of the link's ID, item and expiry, so IDs can't be guessed and a
+// URL only works for the link it was made for. Revoking deletes the row.
+
+// previewSecret is the signing key: PREVIEW_SECRET if set, or else a key
+// generated and kept in the database.
+func previewSecret(database *db.DB) ([]byte, error) {
+	if s := os.Getenv("PREVIEW_SECRET"); s != "" {
+		return []byte(s), nil
+	}
+	return database.PreviewSecret()
+}
+
+func previewSignature(secret []byte, l models.PreviewLink) []byte {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d:%s:%d:%d", l.ID, l.Kind, l.ItemID, l.ExpiresAt.Unix())
+	return mac.Sum(nil)
+}
+
+// previewURL is the path that shows a link's post or project.
+func previewURL(secret []byte, l models.PreviewLink) string {
+	return fmt.Sprintf(
+		"/preview/%d.%s", l.ID,
+		base64.RawURLEncoding.EncodeToString(previewSignature(secret, l)),
+	)
+}
+
+type PreviewHandler struct {
+	DB *db.DB
+}
+
+// previewLink checks a preview token and returns its link, or nil if the
+// token is malformed, forged, expired or revoked.
+func (h *PreviewHandler) previewLink(token string) (*models.PreviewLink, error) {
+	idStr, sigStr, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	sig, err := base64.RawURLEncoding.Strict().DecodeString(sigStr)
+	if err != nil {
+		return nil, nil
+	}
+	link, err := h.DB.GetPreviewLink(id)
+	if err != nil {
+		return nil, nil
+	}
+	secret, err := previewSecret(h.DB)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, previewSignature(secret, *link)) {
+		return nil, nil
+	}
+	return link, nil
+}
+
+// HandlePreview shows the post or project of a valid preview link,
+// whatever its published state, under a preview banner.
+func (h *PreviewHandler) HandlePreview(c echo.Context) error {
+	link, err := h.previewLink(c.Param("token"))
+	if err != nil {
+		return c.String(
+			http.StatusInternalServerError, "Failed to check preview link",
+		)
+	}
+	if link == nil {
+		return c.String(
+			http.StatusNotFound, "Preview link not found or expired",
+		)
+	}
+	c.Response().Header().Set("X-Robots-Tag", "noindex")
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	switch link.Kind {
+	case models.PreviewPost:
+		post, err := h.DB.GetPostByID(link.ItemID)
+		if err != nil {
+			return c.String(http.StatusNotFound, "Post not found")
+		}
+		return pages.PostPreviewPage(post, link.ExpiresAt).
+			Render(c.Request().Context(), c.Response())
+	case models.PreviewProject:
+		project, err := h.DB.GetProjectByID(link.ItemID)
+		if err != nil {
+			return c.String(http.StatusNotFound, "Project not found")
+		}
+		skills, _ := h.DB.GetSkillsForProject(project.ID)
+		media, _ := h.DB.GetMediaForProject(project.ID)
+		d := pages.ProjectDetail{
+			Project: *project,
+			Skills:  db.ListedSkills(skills),
+			Media:   media,
+		}
+		return pages.ProjectPreviewPage(d, link.ExpiresAt).
+			Render(c.Request().Context(), c.Response())
+	}
+	return c.String(http.StatusNotFound, "Preview link not found or expired")
+}
@@ -4,8 +4,10 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
 	"github.com/DYankee/resume2/templates/pages"
 	"github.com/labstack/echo/v4"
 )
@@ -15,43 +17,82 @@ type ProjectsHandler struct {
 }
 
 func (h *ProjectsHandler) HandleProjectsPage(c echo.Context) error {
-	projects, err := h.DB.GetAllProjects()
+	filter, ok := parseProjectFilter(c)
+	if !ok {
+		return c.String(http.StatusBadRequest, "Invalid filter")
+	}
+
+	projects, err := h.DB.FilterListedProjects(filter)
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load projects")
 	}
+	items := projectsWithSkills(h.DB, projects)
 
-	var items []pages.ProjectWithSkills
-	for _, p := range projects {
-		skills, err := h.DB.GetSkillsForProject(p.ID)
-		if err != nil {
-			skills = nil // degrade gracefully
-		}
-		items = append(items, pages.ProjectWithSkills{
-			Project: p,
-			Skills:  skills,
-		})
+	// The filter form only swaps the results below it.
+	if c.Request().Header.Get("HX-Target") == "projects-results" {
+		return pages.ProjectsResults(items, filter).
+			Render(c.Request().Context(), c.Response())
+	}
+
+	skills, err := h.DB.GetListedSkills()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load skills")
+	}
+	categories, err := h.DB.GetAllSkillCategories()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load categories")
 	}
+	opts := pages.ProjectFilterOptions{Skills: skills, Categories: categories}
 
 	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.ProjectsContent(items).
+		return pages.ProjectsContent(items, filter, opts).
 			Render(c.Request().Context(), c.Response())
 	}
-	return pages.ProjectsPage(items).
+	return pages.ProjectsPage(items, filter, opts).
 		Render(c.Request().Context(), c.Response())
 }
 
+// parseProjectFilter reads ?skill=1&skill=2&category=3&q=text. The same
+// parameters are pushed to the address bar so filtered views can be
+// shared.
+func parseProjectFilter(c echo.Context) (models.ProjectFilter, bool) {
+	var f models.ProjectFilter
+	for _, sid := range c.QueryParams()["skill"] {
+		id, err := strconv.ParseInt(sid, 10, 64)
+		if err != nil {
+			return f, false
+		}
+		if !f.HasSkill(id) {
+			f.SkillIDs = append(f.SkillIDs, id)
+		}
+	}
+	if cat := c.QueryParam("category"); cat != "" && cat != "all" {
+		id, err := strconv.ParseInt(cat, 10, 64)
+		if err != nil {
+			return f, false
+		}
+		f.CategoryID = id
+	}
+	f.Query = strings.TrimSpace(c.QueryParam("q"))
+	return f, true
+}
+
 func (h *ProjectsHandler) HandleProjectExpand(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		return c.String(http.StatusBadRequest, "Invalid project ID")
 	}
 	project, err := h.DB.GetProjectByID(id)
-	if err != nil {
+	if err != nil || project.Visibility == models.VisibilityPrivate {
 		return c.String(http.StatusNotFound, "Project not found")
 	}
 	skills, _ := h.DB.GetSkillsForProject(id)
+	skills = db.ListedSkills(skills)
+	media, _ := h.DB.GetMediaForProject(id)
 
-	pw := pages.ProjectWithSkills{Project: *project, Skills: skills}
+	pw := pages.ProjectWithSkills{
+		Project: *project, Skills: skills, Media: media,
+	}
 	return pages.ProjectCardExpanded(pw).
 		Render(c.Request().Context(), c.Response())
 }
@@ -62,12 +103,81 @@ func (h *ProjectsHandler) HandleProjectCollapse(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "Invalid project ID")
 	}
 	project, err := h.DB.GetProjectByID(id)
-	if err != nil {
+	if err != nil || project.Visibility == models.VisibilityPrivate {
 		return c.String(http.StatusNotFound, "Project not found")
 	}
 	skills, _ := h.DB.GetSkillsForProject(id)
+	skills = db.ListedSkills(skills)
 
 	pw := pages.ProjectWithSkills{Project: *project, Skills: skills}
 	return pages.ProjectCard(pw).
 		Render(c.Request().Context(), c.Response())
 }
+
+// projectsWithSkills pairs each project with its listed skills for the
+// public cards.
+func projectsWithSkills(database *db.DB, projects []models.Project) []pages.ProjectWithSkills {
+	var items []pages.ProjectWithSkills
+	for _, p := range projects {
+		skills, err := database.GetSkillsForProject(p.ID)
+		if err != nil {
+			skills = nil // degrade gracefully
+		}
+		items = append(items, pages.ProjectWithSkills{
+			Project: p,
+			Skills:  db.ListedSkills(skills),
+		})
+	}
+	return items
+}
+
+func (h *ProjectsHandler) HandleProjectDetail(c echo.Context) error {
+	slug := c.Param("slug")
+
+	project, err := h.DB.GetProjectBySlug(slug)
+	if err != nil {
+		// Links from before slugs existed used the numeric ID.
+		if id, parseErr := strconv.ParseInt(slug, 10, 64); parseErr == nil {
+			if p, err := h.DB.GetProjectByID(id); err == nil &&
+				p.Visibility != models.VisibilityPrivate {
+				return c.Redirect(
+					http.StatusMovedPermanently, "/projects/"+p.Slug,
+				)
+			}
+		}
+		return c.String(http.StatusNotFound, "Project not found")
+	}
+	if project.Visibility == models.VisibilityPrivate {
+		return c.String(http.StatusNotFound, "Project not found")
+	}
+
+	skills, _ := h.DB.GetSkillsForProject(project.ID)
+	media, _ := h.DB.GetMediaForProject(project.ID)
+	d := pages.ProjectDetail{
+		Project: *project,
+		Skills:  db.ListedSkills(skills),
+		Media:   media,
+	}
+
+	// Previous/next follow the order of the projects grid.
+	listed, _ := h.DB.GetListedProjects()
+	for i, p := range listed {
+		if p.ID != project.ID {
+			continue
+		}
+		if i > 0 {
+			d.Prev = &listed[i-1]
+		}
+		if i < len(listed)-1 {
+			d.Next = &listed[i+1]
+		}
+		break
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.ProjectDetailContent(d).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.ProjectDetailPage(d).
+		Render(c.Request().Context(), c.Response())
+}
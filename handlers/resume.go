@@ -1,204 +1,387 @@
 package handlers
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/DYankee/resume2/db"
 	"github.com/DYankee/resume2/models"
 	"github.com/DYankee/resume2/templates/pages"
-	"github.com/go-pdf/fpdf"
 	"github.com/labstack/echo/v4"
 )
 
 type ResumeHandler struct {
-	DB *db.DB
+	DB    *db.DB
+	Cache *ResumeCache
 }
 
 func (h *ResumeHandler) HandleResumePage(c echo.Context) error {
-	skills, _ := h.DB.GetAllSkills()
-	experiences, _ := h.DB.GetAllExperiences()
-	education, _ := h.DB.GetAllEducation()
+	return h.renderResumePage(c, nil, "/resume/pdf")
+}
 
-	if c.Request().Header.Get("HX-Request") == "true" {
-		return pages.ResumeContent(skills, experiences, education).Render(c.Request().Context(), c.Response())
-	}
+func (h *ResumeHandler) HandleResumePDF(c echo.Context) error {
+	return h.serveResumePDF(c, "")
+}
 
-	return pages.ResumePage(skills, experiences, education).Render(c.Request().Context(), c.Response())
+// HandleResumeJSON serves the resume in the JSON Resume format.
+func (h *ResumeHandler) HandleResumeJSON(c echo.Context) error {
+	return h.serveResume(c, "", "json", func() resumeFormat {
+		return resumeFormat{
+			Ext:         "json",
+			ContentType: "application/json; charset=utf-8",
+			Render: func(d *models.Resume) ([]byte, error) {
+				profile, err := h.DB.GetProfile()
+				if err != nil {
+					return nil, err
+				}
+				return json.MarshalIndent(toJSONResume(d, profile), "", "  ")
+			},
+		}
+	})
 }
 
-func (h *ResumeHandler) HandleResumePDF(c echo.Context) error {
-	skills, err := h.DB.GetAllSkills()
-	if err != nil {
-		return c.String(http.StatusInternalServerError, "Failed to load skills")
-	}
-	experiences, err := h.DB.GetAllExperiences()
+func (h *ResumeHandler) HandleResumeMarkdown(c echo.Context) error {
+	return h.serveResumeExport(c, "md", "text/markdown; charset=utf-8", false, resumeMarkdown)
+}
+
+func (h *ResumeHandler) HandleResumeText(c echo.Context) error {
+	return h.serveResumeExport(c, "txt", "text/plain; charset=utf-8", false, resumeText)
+}
+
+func (h *ResumeHandler) HandleResumeDOCX(c echo.Context) error {
+	return h.serveResumeExport(
+		c, "docx",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		true, resumeDOCX,
+	)
+}
+
+func (h *ResumeHandler) HandleResumeTeX(c echo.Context) error {
+	return h.serveResumeExport(c, "tex", "application/x-tex; charset=utf-8", false, resumeTeX)
+}
+
+// serveResumeExport serves the default resume rendered with render. Text
+// formats are served inline so they can be read or copied in the
+// browser; the rest download.
+func (h *ResumeHandler) serveResumeExport(c echo.Context, ext, contentType string, download bool, render func(*models.Resume) ([]byte, error)) error {
+	return h.serveResume(c, "", ext, func() resumeFormat {
+		return resumeFormat{
+			Ext:         ext,
+			ContentType: contentType,
+			Download:    download,
+			Render:      render,
+		}
+	})
+}
+
+// HandleVariantPage serves /resume/:variant.
+func (h *ResumeHandler) HandleVariantPage(c echo.Context) error {
+	variant, err := h.DB.GetResumeVariantBySlug(c.Param("variant"))
 	if err != nil {
-		return c.String(http.StatusInternalServerError, "Failed to load experiences")
+		return c.String(http.StatusNotFound, "Resume not found")
 	}
-	education, err := h.DB.GetAllEducation()
+	return h.renderResumePage(c, variant, "/resume/"+variant.Slug+"/pdf")
+}
+
+// HandleVariantPDF serves /resume/:variant/pdf.
+func (h *ResumeHandler) HandleVariantPDF(c echo.Context) error {
+	return h.serveResumePDF(c, c.Param("variant"))
+}
+
+func (h *ResumeHandler) renderResumePage(c echo.Context, variant *models.ResumeVariant, pdfURL string) error {
+	resume, err := loadResume(h.DB, variant, models.SurfaceResume)
 	if err != nil {
-		return c.String(http.StatusInternalServerError, "Failed to load education")
+		return c.String(http.StatusInternalServerError, "Failed to load resume")
 	}
+	themes := resumeThemeOptions(h.DB)
+	theme := configuredResumeTheme(h.DB)
 
-	pdf := buildResumePDF(skills, experiences, education)
-
-	c.Response().Header().Set("Content-Type", "application/pdf")
-	c.Response().Header().Set(
-		"Content-Disposition",
-		`attachment; filename="Zachary_Geary_Resume.pdf"`,
-	)
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.ResumeContent(resume, pdfURL, themes, theme).Render(c.Request().Context(), c.Response())
+	}
 
-	return pdf.Output(c.Response().Writer)
+	return pages.ResumePage(resume, pdfURL, themes, theme).Render(c.Request().Context(), c.Response())
 }
 
-func buildResumePDF(
-	skills []models.Skill,
-	experiences []models.Experience,
-	education []models.Education,
-) *fpdf.Fpdf {
-	pdf := fpdf.New("P", "mm", "Letter", "")
-	pdf.SetMargins(15, 15, 15)
-	pdf.SetAutoPageBreak(true, 15)
-	pdf.AddPage()
+// serveResumePDF serves the PDF of a variant, or of the default resume
+// when slug is empty, in the ?theme= theme or the configured one.
+func (h *ResumeHandler) serveResumePDF(c echo.Context, slug string) error {
+	name := c.QueryParam("theme")
+	if name != "" {
+		if _, ok := resumeTheme(name); !ok {
+			return c.String(http.StatusBadRequest, "Unknown theme")
+		}
+	}
 
-	// ── Header ───────────────────────────────────────────────
-	pdf.SetFont("Helvetica", "B", 22)
-	pdf.CellFormat(0, 10, "Zachary Geary", "", 1, "C", false, 0, "")
+	// The cache key uses the theme as asked for; an empty one resolves
+	// to the configured theme only on a miss, which is safe because
+	// changing settings invalidates the cache.
+	return h.serveResume(c, slug, "pdf|"+name, func() resumeFormat {
+		if name == "" {
+			name = configuredResumeTheme(h.DB)
+		}
+		theme, _ := resumeTheme(name)
+		font := configuredResumeFont(h.DB, theme)
+		return resumeFormat{
+			Ext:         "pdf",
+			ContentType: "application/pdf",
+			Download:    true,
+			Options:     theme.Name() + "|" + font.Name,
+			Render: func(d *models.Resume) ([]byte, error) {
+				var buf bytes.Buffer
+				err := theme.Render(d, font).Output(&buf)
+				return buf.Bytes(), err
+			},
+		}
+	})
+}
 
-	pdf.SetFont("Helvetica", "", 12)
-	pdf.SetTextColor(100, 100, 100)
-	pdf.CellFormat(0, 7, "Software Developer", "", 1, "C", false, 0, "")
+// serveResume serves a resume file from the cache, building it on a
+// miss. slug picks a variant ("" for the default resume) and key tells
+// apart the files of one resume; a cache hit needs no database queries.
+func (h *ResumeHandler) serveResume(c echo.Context, slug, key string, format func() resumeFormat) error {
+	key = slug + "|" + key
+	artifact, generation := h.Cache.lookup(key)
+	if artifact != nil {
+		return artifact.serve(c)
+	}
 
-	pdf.SetFont("Helvetica", "", 9)
-	pdf.CellFormat(
-		0, 5,
-		"zpgeary@gmail.com  |  linkedin.com/in/zacharygeary  |  github.com/DYankee",
-		"", 1, "C", false, 0, "",
-	)
-	pdf.SetTextColor(0, 0, 0)
-
-	drawLine(pdf)
-
-	// ── About ────────────────────────────────────────────────
-	sectionHeading(pdf, "About")
-	pdf.SetFont("Helvetica", "", 10)
-	pdf.MultiCell(0, 5,
-		"I'm a highly motivated college student looking to gain experience "+
-			"in the professional world. I have experience developing small "+
-			"websites and programs, working with other developers in an agile "+
-			"like environment and interfacing with customers to ensure product "+
-			"satisfaction. Currently open to work opportunities or internships.",
-		"", "L", false,
-	)
-	pdf.Ln(2)
-	pdf.MultiCell(0, 5,
-		"I currently attend SUNY Polytechnic full time pursuing a CS degree. "+
-			"In my free time I enjoy playing guitar, making games, coding tools "+
-			"for myself, and going hiking.",
-		"", "L", false,
-	)
+	var variant *models.ResumeVariant
+	if slug != "" {
+		v, err := h.DB.GetResumeVariantBySlug(slug)
+		if err != nil {
+			return c.String(http.StatusNotFound, "Resume not found")
+		}
+		variant = v
+	}
 
-	// ── Education ────────────────────────────────────────────
-	if len(education) > 0 {
-		sectionHeading(pdf, "Education")
-		for _, edu := range education {
-			pdf.SetFont("Helvetica", "B", 11)
-			degreeW := pdf.GetStringWidth(edu.Degree) + 2
+	resume, err := loadResume(h.DB, variant, models.SurfacePDF)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load resume")
+	}
+	artifact, err = h.Cache.build(key, generation, resume, format())
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to build resume")
+	}
+	return artifact.serve(c)
+}
 
-			rightText := ""
-			if edu.Gpa > 0 {
-				rightText = fmt.Sprintf("GPA: %.2f", edu.Gpa)
-			}
-			if edu.In_progress {
-				if rightText != "" {
-					rightText += "  |  "
-				}
-				rightText += "In Progress"
-			}
+// clock tells the time resumes are built at; tests replace it.
+var clock = time.Now
 
-			pdf.CellFormat(degreeW, 6, edu.Degree, "", 0, "L", false, 0, "")
+// loadResume assembles the resume from the profile and the listed
+// content, with the sections arranged for surface. A variant, when
+// given, overrides the headline, summary and section order, and narrows
+// each section to the items it selects.
+func loadResume(database *db.DB, variant *models.ResumeVariant, surface string) (*models.Resume, error) {
+	profile, err := database.GetProfile()
+	if err != nil {
+		return nil, err
+	}
+	skills, err := database.GetListedSkills()
+	if err != nil {
+		return nil, err
+	}
+	experiences, err := database.GetAllExperiences()
+	if err != nil {
+		return nil, err
+	}
+	for i := range experiences {
+		experiences[i].Skills = db.ListedSkills(experiences[i].Skills)
+	}
+	education, err := database.GetAllEducation()
+	if err != nil {
+		return nil, err
+	}
+	for i := range education {
+		education[i].Projects = notPrivateProjects(education[i].Projects)
+	}
+	projects, err := resumeProjects(database)
+	if err != nil {
+		return nil, err
+	}
+	certifications, err := database.GetAllCertifications()
+	if err != nil {
+		return nil, err
+	}
+	awards, err := database.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+	publications, err := database.GetAllPublications()
+	if err != nil {
+		return nil, err
+	}
+	sections, err := database.GetSectionOrder(surface)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := database.GetAllCustomSections()
+	if err != nil {
+		return nil, err
+	}
 
-			if rightText != "" {
-				pdf.SetFont("Helvetica", "", 9)
-				pdf.CellFormat(0, 6, rightText, "", 0, "R", false, 0, "")
-			}
-			pdf.Ln(6)
+	now := clock()
+	resume := &models.Resume{
+		Name:        profile.Name,
+		Headline:    profile.Headline,
+		Contact:     profile.ContactLinks(),
+		About:       profile.Paragraphs(),
+		Sections:    sections,
+		Experiences: experiences,
+		Education:   education,
+		Skills:      skills,
 
-			pdf.SetFont("Helvetica", "", 10)
-			pdf.SetTextColor(80, 80, 80)
-			pdf.CellFormat(0, 5, edu.College, "", 1, "L", false, 0, "")
-			pdf.SetTextColor(0, 0, 0)
-			pdf.Ln(2)
-		}
+		Certifications: currentCertifications(certifications, now),
+		Awards:         awards,
+		Publications:   publications,
+		Custom:         custom,
+		Generated:      now,
 	}
 
-	// ── Experience ───────────────────────────────────────────
-	if len(experiences) > 0 {
-		sectionHeading(pdf, "Experience")
-		for _, exp := range experiences {
-			pdf.SetFont("Helvetica", "B", 11)
-			titleW := pdf.GetStringWidth(exp.Title) + 2
-			pdf.CellFormat(titleW, 6, exp.Title, "", 0, "L", false, 0, "")
+	if variant != nil {
+		if variant.Headline != "" {
+			resume.Headline = variant.Headline
+		}
+		if variant.Summary != "" {
+			resume.About = models.Paragraphs(variant.Summary)
+		}
+		if len(variant.Sections) > 0 {
+			resume.Sections = variant.Sections
+		}
+		resume.Experiences = pickByID(experiences, variant.ExperienceIDs,
+			func(e models.Experience) int64 { return e.ID })
+		resume.Education = pickByID(education, variant.EducationIDs,
+			func(e models.Education) int64 { return e.ID })
 
-			endDate := exp.EndDate
-			if endDate == "" {
-				endDate = "Present"
-			} else if len(endDate) >= 7 {
-				endDate = endDate[:7]
+		// Variants may pick unlisted skills and projects, never private
+		// ones.
+		if len(variant.SkillIDs) > 0 {
+			all, err := database.GetAllSkills()
+			if err != nil {
+				return nil, err
 			}
-			startDate := exp.StartDate
-			if len(startDate) >= 7 {
-				startDate = startDate[:7]
+			resume.Skills = pickByID(notPrivateSkills(all), variant.SkillIDs,
+				func(s models.Skill) int64 { return s.ID })
+		}
+		if len(variant.ProjectIDs) > 0 {
+			all, err := database.GetAllProjects()
+			if err != nil {
+				return nil, err
 			}
-			dateStr := startDate + "  -  " + endDate
+			projects = pickByID(notPrivateProjects(all), variant.ProjectIDs,
+				func(p models.Project) int64 { return p.ID })
+		}
+	}
 
-			pdf.SetFont("Helvetica", "", 9)
-			pdf.SetTextColor(100, 100, 100)
-			pdf.CellFormat(0, 6, dateStr, "", 0, "R", false, 0, "")
-			pdf.Ln(6)
+	if order := configuredSkillOrder(database); order != models.SkillOrderManual {
+		if err := database.LoadSkillMetrics(resume.Skills); err != nil {
+			return nil, err
+		}
+		models.SortSkills(resume.Skills, order)
+	}
 
-			pdf.SetFont("Helvetica", "", 10)
-			pdf.SetTextColor(80, 80, 80)
-			pdf.CellFormat(0, 5, exp.Company, "", 1, "L", false, 0, "")
-			pdf.SetTextColor(0, 0, 0)
+	for _, p := range projects {
+		skills, err := database.GetSkillsForProject(p.ID)
+		if err != nil {
+			skills = nil // degrade gracefully
+		}
+		resume.Projects = append(resume.Projects, models.ResumeProject{
+			Project: p,
+			Skills:  db.ListedSkills(skills),
+		})
+	}
+	return resume, nil
+}
 
-			if exp.Description != "" {
-				pdf.SetFont("Helvetica", "", 9)
-				pdf.MultiCell(0, 4.5, exp.Description, "", "L", false)
-			}
-			pdf.Ln(3)
+// configuredSkillOrder returns the resume skill order set in the admin
+// panel, falling back to the manual order.
+func configuredSkillOrder(database *db.DB) string {
+	order, _ := database.GetSetting(db.SettingResumeSkillOrder)
+	if !slices.Contains(models.SkillOrders, order) {
+		return models.SkillOrderManual
+	}
+	return order
+}
+
+// pickByID keeps the items whose ID is in ids, in their original order.
+// An empty ids keeps everything.
+func pickByID[T any](items []T, ids []int64, id func(T) int64) []T {
+	if len(ids) == 0 {
+		return items
+	}
+	var picked []T
+	for _, item := range items {
+		if slices.Contains(ids, id(item)) {
+			picked = append(picked, item)
 		}
 	}
+	return picked
+}
 
-	// ── Skills ───────────────────────────────────────────────
-	if len(skills) > 0 {
-		sectionHeading(pdf, "Skills")
-		pdf.SetFont("Helvetica", "", 10)
+func notPrivateSkills(skills []models.Skill) []models.Skill {
+	var kept []models.Skill
+	for _, s := range skills {
+		if s.Visibility != models.VisibilityPrivate {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
 
-		names := make([]string, len(skills))
-		for i, s := range skills {
-			names[i] = s.Name
+// currentCertifications leaves out certifications that have expired.
+func currentCertifications(certs []models.Certification, now time.Time) []models.Certification {
+	var kept []models.Certification
+	for _, c := range certs {
+		if !c.Expired(now) {
+			kept = append(kept, c)
 		}
-		pdf.MultiCell(0, 5, strings.Join(names, "  |  "), "", "L", false)
 	}
+	return kept
+}
 
-	return pdf
+func notPrivateProjects(projects []models.Project) []models.Project {
+	var kept []models.Project
+	for _, p := range projects {
+		if p.Visibility != models.VisibilityPrivate {
+			kept = append(kept, p)
+		}
+	}
+	return kept
 }
 
-func sectionHeading(pdf *fpdf.Fpdf, title string) {
-	pdf.Ln(4)
-	pdf.SetFont("Helvetica", "B", 13)
-	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
-	drawLine(pdf)
+// resumeProjects returns the projects chosen for the resume in the admin
+// panel, or the featured projects while none are chosen. Private projects
+// are left out either way.
+func resumeProjects(database *db.DB) ([]models.Project, error) {
+	projects, err := database.GetResumeProjects()
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		if projects, err = database.GetFeaturedProjects(); err != nil {
+			return nil, err
+		}
+	}
+	return notPrivateProjects(projects), nil
 }
 
-func drawLine(pdf *fpdf.Fpdf) {
-	w, _ := pdf.GetPageSize()
-	ml, _, mr, _ := pdf.GetMargins()
-	y := pdf.GetY()
-	pdf.SetDrawColor(180, 180, 180)
-	pdf.Line(ml, y, w-mr, y)
-	pdf.Ln(3)
+// resumeFilename builds a download name like "Zachary_Geary_Resume.pdf",
+// keeping only characters that are safe in a Content-Disposition header.
+func resumeFilename(name, ext string) string {
+	var b strings.Builder
+	for _, r := range strings.Join(strings.Fields(name), "_") {
+		if r == '_' || r == '-' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		b.WriteString("_")
+	}
+	return b.String() + "Resume." + ext
 }
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DYankee/resume2/models"
+	"github.com/labstack/echo/v4"
+)
+
+// ── Artifact cache ────────────────────────────────
+//
+// Built resume files (every format, theme and variant) are kept in memory.
+// Requests are mapped to an artifact by URL until the next admin change
+// invalidates the mapping, or until the day changes: the content depends
+// on the date, as certifications expire, ongoing experience grows and
+// files are dated. Artifacts themselves are keyed by a hash of the
+// resume content and the rendering options, so after an admin change that
+// doesn't affect the resume the next request reloads the data but reuses
+// the file, and its ETag stays the same.
+
+// maxCachedArtifacts bounds the artifact store; it is emptied when an
+// invalidation finds it over the limit.
+const maxCachedArtifacts = 64
+
+// resumeFormat describes how to render one kind of resume file.
+type resumeFormat struct {
+	Ext         string
+	ContentType string
+	Download    bool   // attachment rather than inline
+	Options     string // rendering options such as theme and font, hashed with the content
+	Render      func(*models.Resume) ([]byte, error)
+}
+
+type resumeArtifact struct {
+	Body        []byte
+	ContentType string
+	Disposition string
+	ETag        string
+	Modified    time.Time
+}
+
+// ResumeCache holds built resume files. The zero value is not usable;
+// create one with NewResumeCache.
+type ResumeCache struct {
+	mu         sync.Mutex
+	generation uint64
+	day        string            // UTC date the requests were mapped on
+	requests   map[string]string // request key -> content hash
+	artifacts  map[string]*resumeArtifact
+}
+
+func NewResumeCache() *ResumeCache {
+	return &ResumeCache{
+		requests:  make(map[string]string),
+		artifacts: make(map[string]*resumeArtifact),
+	}
+}
+
+// Invalidate forgets which artifact serves which request, so the next
+// request of each kind reloads the resume data.
+func (rc *ResumeCache) Invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.invalidate()
+}
+
+func (rc *ResumeCache) invalidate() {
+	rc.generation++
+	clear(rc.requests)
+	if len(rc.artifacts) > maxCachedArtifacts {
+		clear(rc.artifacts)
+	}
+}
+
+// lookup returns the artifact for a request key, and the generation to
+// hand back to store on a miss. The first lookup of a day invalidates.
+func (rc *ResumeCache) lookup(key string) (*resumeArtifact, uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if day := clock().UTC().Format("2006-01-02"); day != rc.day {
+		rc.day = day
+		rc.invalidate()
+	}
+	if hash, ok := rc.requests[key]; ok {
+		return rc.artifacts[hash], rc.generation
+	}
+	return nil, rc.generation
+}
+
+// build returns the artifact for resume in format, rendering it only if
+// no artifact with the same content hash exists. The request key is
+// remembered unless the cache was invalidated since generation, in which
+// case resume may already be stale.
+func (rc *ResumeCache) build(key string, generation uint64, resume *models.Resume, format resumeFormat) (*resumeArtifact, error) {
+	hash, err := resumeHash(resume, format)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.mu.Lock()
+	a, ok := rc.artifacts[hash]
+	rc.mu.Unlock()
+
+	if !ok {
+		body, err := format.Render(resume)
+		if err != nil {
+			return nil, err
+		}
+		disposition := "inline"
+		if format.Download {
+			disposition = "attachment"
+		}
+		a = &resumeArtifact{
+			Body:        body,
+			ContentType: format.ContentType,
+			Disposition: disposition + `; filename="` + resumeFilename(resume.Name, format.Ext) + `"`,
+			ETag:        `"` + hash[:32] + `"`,
+			Modified:    resume.Generated.UTC().Truncate(time.Second),
+		}
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.artifacts[hash] = a
+	if rc.generation == generation {
+		rc.requests[key] = hash
+	}
+	return a, nil
+}
+
+// resumeHash fingerprints everything that goes into a file except the
+// time of day it was generated; the date is kept, as files print it.
+func resumeHash(resume *models.Resume, format resumeFormat) (string, error) {
+	content := *resume
+	y, m, d := resume.Generated.UTC().Date()
+	content.Generated = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.New()
+	sum.Write([]byte(format.Ext + "\x00" + format.Options + "\x00"))
+	sum.Write(data)
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// serve writes the artifact, answering conditional requests with 304.
+func (a *resumeArtifact) serve(c echo.Context) error {
+	header := c.Response().Header()
+	header.Set("Content-Type", a.ContentType)
+	header.Set("Content-Disposition", a.Disposition)
+	header.Set("ETag", a.ETag)
+	header.Set("Cache-Control", "no-cache")
+	http.ServeContent(c.Response(), c.Request(), "", a.Modified, bytes.NewReader(a.Body))
+	return nil
+}
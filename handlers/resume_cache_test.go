@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/labstack/echo/v4"
+)
+
+// TestResumeCacheExpiresDaily checks that a cached resume drops a
+// certification once it expires, with no admin change in between.
+func TestResumeCacheExpiresDaily(t *testing.T) {
+	database := db.New(filepath.Join(t.TempDir(), "cache.db"))
+	defer database.Conn.Close()
+	if _, err := database.CreateCertification(models.Certification{
+		Name: "Cloud Practitioner", Issuer: "AWS",
+		IssuedDate: "2022-03", ExpiryDate: "2025-03",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	today := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	clock = func() time.Time { return today }
+	defer func() { clock = time.Now }()
+
+	h := &ResumeHandler{DB: database, Cache: NewResumeCache()}
+	e := echo.New()
+	get := func() string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/resume.json", nil), rec)
+		if err := h.HandleResumeJSON(c); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body)
+		}
+		return rec.Body.String()
+	}
+
+	if body := get(); !strings.Contains(body, "Cloud Practitioner") {
+		t.Fatalf("certification missing before it expires:\n%s", body)
+	}
+	today = today.AddDate(0, 0, 1)
+	if body := get(); strings.Contains(body, "Cloud Practitioner") {
+		t.Errorf("expired certification still served:\n%s", body)
+	}
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ── DOCX export ───────────────────────────────────
+//
+// A minimal Office Open XML document written by hand: one body of
+// paragraphs using the built-in Title and Heading styles, plus hyperlink
+// relationships. Word, LibreOffice and Google Docs all open it, and the
+// plain structure keeps ATS parsers happy.
+
+// docx accumulates the body of word/document.xml and the hyperlink
+// relationships it refers to.
+type docx struct {
+	body  strings.Builder
+	links []string // target URL of relationship rIdLink<n+1>
+}
+
+func xmlText(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// run is one piece of formatted text inside a paragraph.
+type run struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	URL    string
+}
+
+// para writes a paragraph in the given style ("" for Normal).
+func (d *docx) para(style string, runs ...run) {
+	d.body.WriteString("<w:p>")
+	if style != "" {
+		fmt.Fprintf(&d.body, `<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	}
+	for _, r := range runs {
+		if r.URL != "" {
+			d.links = append(d.links, r.URL)
+			fmt.Fprintf(&d.body, `<w:hyperlink r:id="rIdLink%d">`, len(d.links))
+		}
+		d.body.WriteString("<w:r>")
+		if r.Bold || r.Italic || r.URL != "" {
+			d.body.WriteString("<w:rPr>")
+			if r.Bold {
+				d.body.WriteString("<w:b/>")
+			}
+			if r.Italic {
+				d.body.WriteString("<w:i/>")
+			}
+			if r.URL != "" {
+				d.body.WriteString(`<w:color w:val="4F46E5"/><w:u w:val="single"/>`)
+			}
+			d.body.WriteString("</w:rPr>")
+		}
+		fmt.Fprintf(&d.body, `<w:t xml:space="preserve">%s</w:t></w:r>`, xmlText(r.Text))
+		if r.URL != "" {
+			d.body.WriteString("</w:hyperlink>")
+		}
+	}
+	d.body.WriteString("</w:p>")
+}
+
+// linkRuns lays links out on one line separated by sep.
+func linkRuns(links []models.ContactLink, sep string) []run {
+	var runs []run
+	for i, l := range links {
+		if i > 0 {
+			runs = append(runs, run{Text: sep})
+		}
+		runs = append(runs, run{Text: l.Text, URL: l.URL})
+	}
+	return runs
+}
+
+func resumeDOCX(d *models.Resume) ([]byte, error) {
+	doc := &docx{}
+
+	doc.para("Title", run{Text: d.Name})
+	if d.Headline != "" {
+		doc.para("Subtitle", run{Text: d.Headline})
+	}
+	if len(d.Contact) > 0 {
+		doc.para("", linkRuns(d.Contact, "  |  ")...)
+	}
+
+	sections := map[string]func(){
+		models.SectionAbout: func() {
+			if len(d.About) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "About"})
+			for _, p := range d.About {
+				doc.para("", run{Text: p})
+			}
+		},
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Education"})
+			for _, edu := range d.Education {
+				doc.para("Heading2", run{Text: edu.Degree})
+				doc.para("", run{Text: edu.College})
+				if status := educationStatus(edu); status != "" {
+					doc.para("", run{Text: status, Italic: true})
+				}
+				for _, line := range educationDetails(edu) {
+					doc.para("", run{Text: line})
+				}
+			}
+		},
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Experience"})
+			for _, exp := range d.Experiences {
+				doc.para("Heading2", run{Text: exp.Title})
+				doc.para("",
+					run{Text: experienceCompany(exp), Bold: true},
+					run{Text: "  " + experienceDates(exp), Italic: true},
+				)
+				if exp.Description != "" {
+					doc.para("", run{Text: exp.Description})
+				}
+				for _, h := range exp.Highlights {
+					doc.para("ListBullet", run{Text: "•  " + h})
+				}
+				if len(exp.Skills) > 0 {
+					doc.para("", run{
+						Text:   strings.Join(skillNames(exp.Skills), ", "),
+						Italic: true,
+					})
+				}
+			}
+		},
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Projects"})
+			for _, pw := range d.Projects {
+				doc.para("Heading2", run{Text: pw.Project.Title})
+				if links := projectLinks(pw.Project); len(links) > 0 {
+					doc.para("", linkRuns(links, "  |  ")...)
+				}
+				if pw.Project.Description != "" {
+					doc.para("", run{Text: pw.Project.Description})
+				}
+				if len(pw.Skills) > 0 {
+					doc.para("", run{
+						Text:   strings.Join(skillNames(pw.Skills), ", "),
+						Italic: true,
+					})
+				}
+			}
+		},
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Skills"})
+			for _, g := range skillsByCategory(d.Skills) {
+				doc.para("",
+					run{Text: g.Category + ": ", Bold: true},
+					run{Text: strings.Join(g.Skills, ", ")},
+				)
+			}
+		},
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Certifications"})
+			for _, cert := range d.Certifications {
+				doc.para("Heading2", run{Text: cert.Name, URL: cert.CredentialURL})
+				doc.para("",
+					run{Text: certificationIssuer(cert), Bold: true},
+					run{Text: "  " + certificationDates(cert), Italic: true},
+				)
+			}
+		},
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Awards"})
+			for _, award := range d.Awards {
+				doc.para("Heading2", run{Text: award.Title})
+				doc.para("",
+					run{Text: award.Issuer, Bold: true},
+					run{Text: "  " + award.Date, Italic: true},
+				)
+				if award.Description != "" {
+					doc.para("", run{Text: award.Description})
+				}
+			}
+		},
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: "Publications & Talks"})
+			for _, pub := range d.Publications {
+				doc.para("Heading2", run{Text: pub.Title, URL: pub.URL})
+				doc.para("",
+					run{Text: publicationVenue(pub), Bold: true},
+					run{Text: "  " + pub.Date, Italic: true},
+				)
+				if pub.Description != "" {
+					doc.para("", run{Text: pub.Description})
+				}
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paras := cs.Paragraphs()
+			if len(paras) == 0 {
+				return
+			}
+			doc.para("Heading1", run{Text: cs.Title})
+			for _, p := range paras {
+				doc.para("", run{Text: p})
+			}
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+
+	return doc.zip(d)
+}
+
+// zip packages the document with the parts Word requires.
+func (d *docx) zip(resume *models.Resume) ([]byte, error) {
+	var rels strings.Builder
+	rels.WriteString(xml.Header)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	rels.WriteString(`<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	for i, u := range d.links {
+		fmt.Fprintf(&rels,
+			`<Relationship Id="rIdLink%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`,
+			i+1, xmlText(u),
+		)
+	}
+	rels.WriteString(`</Relationships>`)
+
+	document := xml.Header +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:body>` + d.body.String() +
+		`<w:sectPr><w:pgSz w:w="12240" w:h="15840"/>` +
+		`<w:pgMar w:top="1080" w:right="1080" w:bottom="1080" w:left="1080" w:header="720" w:footer="720" w:gutter="0"/>` +
+		`</w:sectPr></w:body></w:document>`
+
+	created := resume.Generated.UTC().Format(time.RFC3339)
+	core := xml.Header +
+		`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" ` +
+		`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<dc:title>` + xmlText(resume.Name+" - Resume") + `</dc:title>` +
+		`<dc:creator>` + xmlText(resume.Name) + `</dc:creator>` +
+		`<dc:subject>` + xmlText(resume.Headline) + `</dc:subject>` +
+		`<cp:keywords>` + xmlText(strings.Join(skillNames(resume.Skills), ", ")) + `</cp:keywords>` +
+		`<dcterms:created xsi:type="dcterms:W3CDTF">` + created + `</dcterms:created>` +
+		`</cp:coreProperties>`
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", core},
+		{"word/document.xml", document},
+		{"word/styles.xml", docxStyles},
+		{"word/_rels/document.xml.rels", rels.String()},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, p := range parts {
+		w, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(p.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const docxContentTypes = xml.Header +
+	`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxPackageRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// docxStyles defines the handful of styles the body uses. Sizes are in
+// half-points.
+const docxStyles = xml.Header +
+	`<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr>` +
+	`<w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="21"/>` +
+	`</w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="80"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:jc w:val="center"/><w:spacing w:after="40"/></w:pPr><w:rPr><w:b/><w:sz w:val="44"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:jc w:val="center"/></w:pPr><w:rPr><w:color w:val="646464"/><w:sz w:val="24"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/>` +
+	`<w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="C8C8C8"/></w:pBdr><w:outlineLvl w:val="0"/></w:pPr>` +
+	`<w:rPr><w:b/><w:sz w:val="28"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="120" w:after="20"/><w:outlineLvl w:val="1"/></w:pPr>` +
+	`<w:rPr><w:b/><w:sz w:val="23"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:spacing w:after="20"/><w:ind w:left="360" w:hanging="216"/></w:pPr></w:style>` +
+	`</w:styles>`
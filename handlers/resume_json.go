@@ -0,0 +1,1023 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+)
+
+// ── JSON Resume ───────────────────────────────────
+//
+// The export follows the jsonresume.org v1 schema. Fields the schema has
+// no place for (skill proficiency and description, a project's repository,
+// a job's employment type and skills, a degree's minor and honors and
+// whether it is in progress) ride along as extra properties, which
+// the schema allows, so that importing an export changes nothing.
+
+const jsonResumeSchema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+type jsonResume struct {
+	Schema    string          `json:"$schema,omitempty"`
+	Basics    jsonBasics      `json:"basics"`
+	Work      []jsonWork      `json:"work,omitempty"`
+	Education []jsonEducation `json:"education,omitempty"`
+	Skills    []jsonSkill     `json:"skills,omitempty"`
+	Projects  []jsonProject   `json:"projects,omitempty"`
+
+	Certificates []jsonCertificate `json:"certificates,omitempty"`
+	Awards       []jsonAward       `json:"awards,omitempty"`
+	Publications []jsonPublication `json:"publications,omitempty"`
+
+	Meta *jsonMeta `json:"meta,omitempty"`
+}
+
+type jsonBasics struct {
+	Name     string        `json:"name"`
+	Label    string        `json:"label,omitempty"`
+	Email    string        `json:"email,omitempty"`
+	Phone    string        `json:"phone,omitempty"`
+	URL      string        `json:"url,omitempty"`
+	Summary  string        `json:"summary,omitempty"`
+	Location *jsonLocation `json:"location,omitempty"`
+	Profiles []jsonProfile `json:"profiles,omitempty"`
+}
+
+type jsonLocation struct {
+	Address     string `json:"address,omitempty"`
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+type jsonProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url"`
+}
+
+type jsonWork struct {
+	Name           string   `json:"name"`
+	Position       string   `json:"position"`
+	Location       string   `json:"location,omitempty"`
+	StartDate      string   `json:"startDate,omitempty"`
+	EndDate        string   `json:"endDate,omitempty"`
+	Summary        string   `json:"summary,omitempty"`
+	Highlights     []string `json:"highlights,omitempty"`
+	EmploymentType string   `json:"employmentType,omitempty"`
+	Keywords       []string `json:"keywords,omitempty"`
+}
+
+type jsonEducation struct {
+	Institution string   `json:"institution"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+	Minor       string   `json:"minor,omitempty"`
+	Honors      string   `json:"honors,omitempty"`
+	InProgress  bool     `json:"inProgress,omitempty"`
+}
+
+type jsonSkill struct {
+	Name        string   `json:"name"`
+	Level       string   `json:"level,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Proficiency int8     `json:"proficiency,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+type jsonProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+// jsonCertificate adds the expiry and credential ID, which JSON Resume
+// has no place for.
+type jsonCertificate struct {
+	Name         string `json:"name"`
+	Date         string `json:"date,omitempty"`
+	Issuer       string `json:"issuer,omitempty"`
+	URL          string `json:"url,omitempty"`
+	ExpiryDate   string `json:"expiryDate,omitempty"`
+	CredentialID string `json:"credentialId,omitempty"`
+}
+
+type jsonAward struct {
+	Title   string `json:"title"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// jsonPublication adds the kind, so talks survive a round trip; files
+// without one are read as publications.
+type jsonPublication struct {
+	Name        string `json:"name"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+}
+
+type jsonMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// toJSONResume converts a resume to the JSON Resume format. The profile
+// supplies the structured contact details the resume only has as links.
+func toJSONResume(resume *models.Resume, profile *models.Profile) jsonResume {
+	out := jsonResume{
+		Schema: jsonResumeSchema,
+		Basics: jsonBasics{
+			Name:    resume.Name,
+			Label:   resume.Headline,
+			Email:   profile.Email,
+			Phone:   profile.Phone,
+			URL:     profile.WebsiteURL,
+			Summary: strings.Join(resume.About, "\n\n"),
+		},
+		Meta: &jsonMeta{
+			Version:      "v1.0.0",
+			LastModified: resume.Generated.UTC().Format(time.RFC3339),
+		},
+	}
+	if profile.Location != "" {
+		out.Basics.Location = &jsonLocation{Address: profile.Location}
+	}
+	if profile.LinkedInURL != "" {
+		out.Basics.Profiles = append(out.Basics.Profiles, jsonProfile{
+			Network: "LinkedIn", URL: profile.LinkedInURL,
+		})
+	}
+	if profile.GitHubURL != "" {
+		out.Basics.Profiles = append(out.Basics.Profiles, jsonProfile{
+			Network:  "GitHub",
+			Username: profileUsername(profile.GitHubURL),
+			URL:      profile.GitHubURL,
+		})
+	}
+
+	for _, e := range resume.Experiences {
+		out.Work = append(out.Work, jsonWork{
+			Name:           e.Company,
+			Position:       e.Title,
+			Location:       e.Location,
+			StartDate:      e.StartDate,
+			EndDate:        e.EndDate,
+			Summary:        e.Description,
+			Highlights:     e.Highlights,
+			EmploymentType: e.EmploymentType,
+			Keywords:       skillNames(e.Skills),
+		})
+	}
+	for _, e := range resume.Education {
+		je := jsonEducation{
+			Institution: e.College,
+			StudyType:   e.Degree,
+			Area:        e.Major,
+			StartDate:   e.StartDate,
+			EndDate:     e.EndDate,
+			Courses:     e.Coursework,
+			Minor:       e.Minor,
+			Honors:      e.Honors,
+			InProgress:  e.In_progress,
+		}
+		if e.Gpa > 0 {
+			je.Score = fmt.Sprintf("%.2f", e.Gpa)
+		}
+		out.Education = append(out.Education, je)
+	}
+	for _, s := range resume.Skills {
+		js := jsonSkill{
+			Name:        s.Name,
+			Level:       skillLevel(s.Proficiency),
+			Proficiency: s.Proficiency,
+			Description: s.Description,
+		}
+		if s.Category != "" {
+			js.Keywords = []string{s.Category}
+		}
+		out.Skills = append(out.Skills, js)
+	}
+	for _, pw := range resume.Projects {
+		out.Projects = append(out.Projects, jsonProject{
+			Name:        pw.Project.Title,
+			Description: pw.Project.Description,
+			URL:         pw.Project.LiveURL,
+			Repository:  pw.Project.RepoURL,
+			Keywords:    skillNames(pw.Skills),
+		})
+	}
+	for _, c := range resume.Certifications {
+		out.Certificates = append(out.Certificates, jsonCertificate{
+			Name:         c.Name,
+			Date:         c.IssuedDate,
+			Issuer:       c.Issuer,
+			URL:          c.CredentialURL,
+			ExpiryDate:   c.ExpiryDate,
+			CredentialID: c.CredentialID,
+		})
+	}
+	for _, a := range resume.Awards {
+		out.Awards = append(out.Awards, jsonAward{
+			Title:   a.Title,
+			Date:    a.Date,
+			Awarder: a.Issuer,
+			Summary: a.Description,
+		})
+	}
+	for _, p := range resume.Publications {
+		out.Publications = append(out.Publications, jsonPublication{
+			Name:        p.Title,
+			Publisher:   p.Venue,
+			ReleaseDate: p.Date,
+			URL:         p.URL,
+			Summary:     p.Description,
+			Kind:        p.Kind,
+		})
+	}
+	return out
+}
+
+func profileUsername(u string) string {
+	short := models.ShortURL(u)
+	return short[strings.LastIndex(short, "/")+1:]
+}
+
+// skillLevel names a 0-100 proficiency the way JSON Resume themes expect.
+func skillLevel(proficiency int8) string {
+	switch {
+	case proficiency >= 90:
+		return "Expert"
+	case proficiency >= 70:
+		return "Advanced"
+	case proficiency >= 40:
+		return "Intermediate"
+	case proficiency > 0:
+		return "Beginner"
+	}
+	return ""
+}
+
+// skillProficiency is the inverse of skillLevel for files that only
+// carry a level; unknown levels give 0.
+func skillProficiency(s jsonSkill) int8 {
+	if s.Proficiency > 0 {
+		return min(s.Proficiency, 100)
+	}
+	switch strings.ToLower(s.Level) {
+	case "expert", "master":
+		return 90
+	case "advanced":
+		return 75
+	case "intermediate":
+		return 50
+	case "beginner", "novice":
+		return 25
+	}
+	return 0
+}
+
+// ── Import ────────────────────────────────────────
+
+// parseJSONResume decodes and sanity-checks an uploaded JSON Resume.
+func parseJSONResume(data []byte) (*jsonResume, error) {
+	var r jsonResume
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("not a JSON Resume file: %w", err)
+	}
+	if strings.TrimSpace(r.Basics.Name) == "" {
+		return nil, fmt.Errorf("basics.name is required")
+	}
+	for i := range r.Work {
+		w := &r.Work[i]
+		if w.Name == "" || w.Position == "" {
+			return nil, fmt.Errorf("every work entry needs a name and position")
+		}
+		var err error
+		if w.StartDate, err = importDate(w.StartDate); err != nil {
+			return nil, fmt.Errorf("work %q startDate: %w", w.Name, err)
+		}
+		if w.EndDate, err = importDate(w.EndDate); err != nil {
+			return nil, fmt.Errorf("work %q endDate: %w", w.Name, err)
+		}
+		if !models.ValidEmploymentType(w.EmploymentType) {
+			return nil, fmt.Errorf("work %q: unknown employmentType %q", w.Name, w.EmploymentType)
+		}
+	}
+	for i := range r.Education {
+		e := &r.Education[i]
+		if e.Institution == "" || educationDegree(e) == "" {
+			return nil, fmt.Errorf("every education entry needs an institution and studyType")
+		}
+		var err error
+		if e.StartDate, err = importDate(e.StartDate); err != nil {
+			return nil, fmt.Errorf("education %q startDate: %w", e.Institution, err)
+		}
+		if e.EndDate, err = importDate(e.EndDate); err != nil {
+			return nil, fmt.Errorf("education %q endDate: %w", e.Institution, err)
+		}
+	}
+	for _, s := range r.Skills {
+		if s.Name == "" {
+			return nil, fmt.Errorf("every skill needs a name")
+		}
+	}
+	for _, p := range r.Projects {
+		if p.Name == "" {
+			return nil, fmt.Errorf("every project needs a name")
+		}
+	}
+	for i := range r.Certificates {
+		c := &r.Certificates[i]
+		if c.Name == "" {
+			return nil, fmt.Errorf("every certificate needs a name")
+		}
+		var err error
+		if c.Date, err = importDate(c.Date); err != nil {
+			return nil, fmt.Errorf("certificate %q date: %w", c.Name, err)
+		}
+		if c.ExpiryDate, err = importDate(c.ExpiryDate); err != nil {
+			return nil, fmt.Errorf("certificate %q expiryDate: %w", c.Name, err)
+		}
+	}
+	for i := range r.Awards {
+		a := &r.Awards[i]
+		if a.Title == "" {
+			return nil, fmt.Errorf("every award needs a title")
+		}
+		var err error
+		if a.Date, err = importDate(a.Date); err != nil {
+			return nil, fmt.Errorf("award %q date: %w", a.Title, err)
+		}
+	}
+	for i := range r.Publications {
+		p := &r.Publications[i]
+		if p.Name == "" {
+			return nil, fmt.Errorf("every publication needs a name")
+		}
+		if p.Kind == "" {
+			p.Kind = models.PublicationArticle
+		}
+		if !models.ValidPublicationKind(p.Kind) {
+			return nil, fmt.Errorf("publication %q: unknown kind %q", p.Name, p.Kind)
+		}
+		var err error
+		if p.ReleaseDate, err = importDate(p.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("publication %q releaseDate: %w", p.Name, err)
+		}
+	}
+	return &r, nil
+}
+
+// importDate checks an optional JSON Resume date and returns it as
+// "YYYY-MM", or "" when it is missing. The schema also allows a bare
+// year, which is kept as its January since dates here have a month.
+func importDate(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	if len(s) == len("2006") {
+		s += "-01"
+	}
+	return models.ParseYearMonth(s)
+}
+
+// educationDegree returns the degree to store: the studyType, or the
+// area when there is no studyType.
+func educationDegree(e *jsonEducation) string {
+	if e.StudyType == "" {
+		return e.Area
+	}
+	return e.StudyType
+}
+
+// educationMajor returns the major to store: the area, unless it already
+// stands in for the degree.
+func educationMajor(e *jsonEducation) string {
+	if e.StudyType == "" {
+		return ""
+	}
+	return e.Area
+}
+
+// importStep is one record an import would create or update. Apply
+// writes it through database, which is the transaction of the whole
+// import; it is nil when the record is already up to date.
+type importStep struct {
+	pages.ImportChange
+	Apply func(database *db.DB) error
+}
+
+// planImport compares r with the database and returns one step per
+// record in the file. Records are matched by name: skills and projects
+// by name, experience by company, position and start date, education by
+// institution and degree, certificates by name and issuer, awards and
+// publications by title. Nothing is ever deleted, and fields the file
+// has no place for (visibility, icons, long descriptions) are left alone.
+// New projects go on the resume's project list, as they were in the file.
+func planImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	var steps []importStep
+
+	profileStep, err := planProfileImport(database, r.Basics)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, profileStep)
+
+	for _, plan := range []func(*db.DB, *jsonResume) ([]importStep, error){
+		planSkillImport, planExperienceImport,
+		planEducationImport, planProjectImport,
+		planCertificationImport, planAwardImport, planPublicationImport,
+	} {
+		more, err := plan(database, r)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, more...)
+	}
+	return steps, nil
+}
+
+// diffFields lists the names whose old and new values differ.
+func diffFields(pairs ...[3]string) []string {
+	var changed []string
+	for _, p := range pairs {
+		if p[1] != p[2] {
+			changed = append(changed, p[0])
+		}
+	}
+	return changed
+}
+
+func newStep(kind, title string, existing bool, fields []string, apply func(*db.DB) error) importStep {
+	step := importStep{ImportChange: pages.ImportChange{
+		Kind: kind, Title: title, Fields: fields,
+	}}
+	switch {
+	case !existing:
+		step.Action = pages.ImportCreate
+		step.Apply = apply
+	case len(fields) > 0:
+		step.Action = pages.ImportUpdate
+		step.Apply = apply
+	default:
+		step.Action = pages.ImportUnchanged
+	}
+	return step
+}
+
+func planProfileImport(database *db.DB, b jsonBasics) (importStep, error) {
+	current, err := database.GetProfile()
+	if err != nil {
+		return importStep{}, err
+	}
+
+	next := *current
+	next.Name = b.Name
+	next.Headline = b.Label
+	next.Email = b.Email
+	next.Phone = b.Phone
+	next.WebsiteURL = b.URL
+	next.Summary = strings.Join(models.Paragraphs(b.Summary), "\n\n")
+	next.Location = ""
+	if b.Location != nil {
+		next.Location = b.Location.Address
+		if next.Location == "" {
+			next.Location = strings.Join(nonEmpty(
+				b.Location.City, b.Location.Region, b.Location.CountryCode,
+			), ", ")
+		}
+	}
+	next.LinkedInURL, next.GitHubURL = "", ""
+	for _, p := range b.Profiles {
+		switch strings.ToLower(p.Network) {
+		case "linkedin":
+			next.LinkedInURL = p.URL
+		case "github":
+			next.GitHubURL = p.URL
+		}
+	}
+
+	fields := diffFields(
+		[3]string{"name", current.Name, next.Name},
+		[3]string{"headline", current.Headline, next.Headline},
+		[3]string{"email", current.Email, next.Email},
+		[3]string{"phone", current.Phone, next.Phone},
+		[3]string{"location", current.Location, next.Location},
+		[3]string{"LinkedIn", current.LinkedInURL, next.LinkedInURL},
+		[3]string{"GitHub", current.GitHubURL, next.GitHubURL},
+		[3]string{"website", current.WebsiteURL, next.WebsiteURL},
+		[3]string{"summary",
+			strings.Join(current.Paragraphs(), "\n\n"), next.Summary},
+	)
+	return newStep("Profile", next.Name, true, fields, func(database *db.DB) error {
+		return database.UpdateProfile(next)
+	}), nil
+}
+
+func nonEmpty(values ...string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func planSkillImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllSkillsWithAliases()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := database.GetAllSkillCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, js := range r.Skills {
+		category := ""
+		if len(js.Keywords) > 0 {
+			category = js.Keywords[0]
+		}
+		proficiency := skillProficiency(js)
+
+		i := models.FindSkill(existing, js.Name)
+		if i < 0 {
+			steps = append(steps, newStep("Skill", js.Name, false, nil, func(database *db.DB) error {
+				catID, err := skillCategoryID(database, &categories, category)
+				if err != nil {
+					return err
+				}
+				_, err = database.CreateSkill(
+					js.Name, catID, js.Description, "", proficiency,
+					models.VisibilityPublic,
+				)
+				return err
+			}))
+			continue
+		}
+
+		s := existing[i]
+		if category == "" {
+			category = s.Category
+		}
+		// A skill matched by one of its aliases keeps its own name.
+		name := js.Name
+		if models.NormalizeSkillName(s.Name) != models.NormalizeSkillName(js.Name) {
+			name = s.Name
+		}
+		fields := diffFields(
+			[3]string{"name", s.Name, name},
+			[3]string{"category", s.Category, category},
+			[3]string{"proficiency",
+				strconv.Itoa(int(s.Proficiency)), strconv.Itoa(int(proficiency))},
+			[3]string{"description", s.Description, js.Description},
+		)
+		steps = append(steps, newStep("Skill", js.Name, true, fields, func(database *db.DB) error {
+			catID, err := skillCategoryID(database, &categories, category)
+			if err != nil {
+				return err
+			}
+			return database.UpdateSkill(
+				s.ID, name, catID, js.Description, s.IconURL,
+				proficiency, s.Visibility,
+			)
+		}))
+	}
+	return steps, nil
+}
+
+// skillCategoryID finds a category by name, creating it if needed.
+// Skills must have a category, so an empty name files them under "Other".
+func skillCategoryID(database *db.DB, categories *[]models.Skill_category, name string) (int64, error) {
+	if name == "" {
+		name = "Other"
+	}
+	for _, c := range *categories {
+		if strings.EqualFold(c.Name, name) {
+			return c.ID, nil
+		}
+	}
+	id, err := database.CreateSkillCategory(name)
+	if err != nil {
+		return 0, err
+	}
+	*categories = append(*categories, models.Skill_category{ID: id, Name: name})
+	return id, nil
+}
+
+func planExperienceImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllExperiences()
+	if err != nil {
+		return nil, err
+	}
+	known, err := knownSkill(database, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, w := range r.Work {
+		title := w.Position + " at " + w.Name
+		i := slices.IndexFunc(existing, func(e models.Experience) bool {
+			return strings.EqualFold(e.Company, w.Name) &&
+				strings.EqualFold(e.Title, w.Position) &&
+				e.StartDate == w.StartDate
+		})
+		next := models.Experience{
+			Title:          w.Position,
+			Company:        w.Name,
+			Location:       w.Location,
+			EmploymentType: w.EmploymentType,
+			StartDate:      w.StartDate,
+			EndDate:        w.EndDate,
+			Description:    w.Summary,
+			Highlights:     w.Highlights,
+		}
+		if i < 0 {
+			steps = append(steps, newStep("Experience", title, false, nil, func(database *db.DB) error {
+				skillIDs, err := skillIDsByName(database, w.Keywords)
+				if err != nil {
+					return err
+				}
+				_, err = database.CreateExperience(next, skillIDs)
+				return err
+			}))
+			continue
+		}
+
+		e := existing[i]
+		next.ID = e.ID
+		next.Tags = e.Tags // JSON Resume has no tags; keep ours
+		fields := diffFields(
+			[3]string{"title", e.Title, w.Position},
+			[3]string{"company", e.Company, w.Name},
+			[3]string{"location", e.Location, w.Location},
+			[3]string{"employment type", e.EmploymentType, w.EmploymentType},
+			[3]string{"end date", e.EndDate, w.EndDate},
+			[3]string{"description", e.Description, w.Summary},
+			[3]string{"highlights",
+				strings.Join(e.Highlights, "\n"), strings.Join(w.Highlights, "\n")},
+		)
+		if missingSkill(e.Skills, w.Keywords, known) {
+			fields = append(fields, "skills")
+		}
+		steps = append(steps, newStep("Experience", title, true, fields, func(database *db.DB) error {
+			// Keep the existing links, including private skills the
+			// export leaves out, and add the named ones.
+			skillIDs, err := skillIDsByName(database, w.Keywords)
+			if err != nil {
+				return err
+			}
+			for _, s := range e.Skills {
+				skillIDs = append(skillIDs, s.ID)
+			}
+			return database.UpdateExperience(next, skillIDs)
+		}))
+	}
+	return steps, nil
+}
+
+func planEducationImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllEducation()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, je := range r.Education {
+		degree := educationDegree(&je)
+		gpa, _ := strconv.ParseFloat(je.Score, 64)
+		title := degree + ", " + je.Institution
+
+		// Degrees were once imported as studyType and area joined into
+		// one ("BS Computer Science"); still match those.
+		joined := strings.TrimSpace(je.StudyType + " " + je.Area)
+		i := slices.IndexFunc(existing, func(e models.Education) bool {
+			return strings.EqualFold(e.College, je.Institution) &&
+				(strings.EqualFold(e.Degree, degree) || strings.EqualFold(e.Degree, joined))
+		})
+		next := models.Education{
+			Degree:      degree,
+			College:     je.Institution,
+			Major:       educationMajor(&je),
+			Minor:       je.Minor,
+			StartDate:   je.StartDate,
+			EndDate:     je.EndDate,
+			Gpa:         gpa,
+			In_progress: je.InProgress,
+			Honors:      je.Honors,
+			Coursework:  je.Courses,
+		}
+		if i < 0 {
+			steps = append(steps, newStep("Education", title, false, nil, func(database *db.DB) error {
+				_, err := database.CreateEducation(next, nil)
+				return err
+			}))
+			continue
+		}
+
+		e := existing[i]
+		next.ID = e.ID
+		fields := diffFields(
+			[3]string{"degree", e.Degree, degree},
+			[3]string{"college", e.College, je.Institution},
+			[3]string{"major", e.Major, next.Major},
+			[3]string{"minor", e.Minor, je.Minor},
+			[3]string{"start date", e.StartDate, je.StartDate},
+			[3]string{"end date", e.EndDate, je.EndDate},
+			[3]string{"GPA",
+				fmt.Sprintf("%.2f", e.Gpa), fmt.Sprintf("%.2f", gpa)},
+			[3]string{"in progress",
+				strconv.FormatBool(e.In_progress), strconv.FormatBool(je.InProgress)},
+			[3]string{"honors", e.Honors, je.Honors},
+			[3]string{"coursework",
+				strings.Join(e.Coursework, "\n"), strings.Join(je.Courses, "\n")},
+		)
+		steps = append(steps, newStep("Education", title, true, fields, func(database *db.DB) error {
+			// The file has no place for class projects; keep the links.
+			projectIDs := make([]int64, len(e.Projects))
+			for i, p := range e.Projects {
+				projectIDs[i] = p.ID
+			}
+			return database.UpdateEducation(next, projectIDs)
+		}))
+	}
+	return steps, nil
+}
+
+func planProjectImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	known, err := knownSkill(database, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, jp := range r.Projects {
+		i := slices.IndexFunc(existing, func(p models.Project) bool {
+			return strings.EqualFold(p.Title, jp.Name)
+		})
+		if i < 0 {
+			steps = append(steps, newStep("Project", jp.Name, false, nil, func(database *db.DB) error {
+				id, err := database.CreateProject(
+					"", jp.Name, jp.Description, "", "", jp.Repository, jp.URL,
+					models.VisibilityPublic,
+				)
+				if err != nil {
+					return err
+				}
+				if err := linkProjectSkills(database, id, jp.Keywords); err != nil {
+					return err
+				}
+				// The file's projects are the ones on its resume.
+				return database.AddResumeProject(id)
+			}))
+			continue
+		}
+
+		p := existing[i]
+		linked, err := database.GetSkillsForProject(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		fields := diffFields(
+			[3]string{"title", p.Title, jp.Name},
+			[3]string{"description", p.Description, jp.Description},
+			[3]string{"live URL", p.LiveURL, jp.URL},
+			[3]string{"repository", p.RepoURL, jp.Repository},
+		)
+		if missingSkill(linked, jp.Keywords, known) {
+			fields = append(fields, "skills")
+		}
+		steps = append(steps, newStep("Project", jp.Name, true, fields, func(database *db.DB) error {
+			if err := database.UpdateProject(
+				p.ID, p.Slug, jp.Name, jp.Description, p.LongDesc,
+				p.ImageURL, jp.Repository, jp.URL, p.Visibility,
+			); err != nil {
+				return err
+			}
+			return linkProjectSkills(database, p.ID, jp.Keywords)
+		}))
+	}
+	return steps, nil
+}
+
+func planCertificationImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllCertifications()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, jc := range r.Certificates {
+		i := slices.IndexFunc(existing, func(c models.Certification) bool {
+			return strings.EqualFold(c.Name, jc.Name) &&
+				strings.EqualFold(c.Issuer, jc.Issuer)
+		})
+		next := models.Certification{
+			Name:          jc.Name,
+			Issuer:        jc.Issuer,
+			IssuedDate:    jc.Date,
+			ExpiryDate:    jc.ExpiryDate,
+			CredentialID:  jc.CredentialID,
+			CredentialURL: jc.URL,
+		}
+		if i < 0 {
+			steps = append(steps, newStep("Certification", jc.Name, false, nil, func(database *db.DB) error {
+				_, err := database.CreateCertification(next)
+				return err
+			}))
+			continue
+		}
+
+		c := existing[i]
+		next.ID = c.ID
+		fields := diffFields(
+			[3]string{"name", c.Name, next.Name},
+			[3]string{"issuer", c.Issuer, next.Issuer},
+			[3]string{"issued", c.IssuedDate, next.IssuedDate},
+			[3]string{"expires", c.ExpiryDate, next.ExpiryDate},
+			[3]string{"credential ID", c.CredentialID, next.CredentialID},
+			[3]string{"credential URL", c.CredentialURL, next.CredentialURL},
+		)
+		steps = append(steps, newStep("Certification", jc.Name, true, fields, func(database *db.DB) error {
+			return database.UpdateCertification(next)
+		}))
+	}
+	return steps, nil
+}
+
+func planAwardImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, ja := range r.Awards {
+		i := slices.IndexFunc(existing, func(a models.Award) bool {
+			return strings.EqualFold(a.Title, ja.Title)
+		})
+		next := models.Award{
+			Title:       ja.Title,
+			Issuer:      ja.Awarder,
+			Date:        ja.Date,
+			Description: ja.Summary,
+		}
+		if i < 0 {
+			steps = append(steps, newStep("Award", ja.Title, false, nil, func(database *db.DB) error {
+				_, err := database.CreateAward(next)
+				return err
+			}))
+			continue
+		}
+
+		a := existing[i]
+		next.ID = a.ID
+		fields := diffFields(
+			[3]string{"title", a.Title, next.Title},
+			[3]string{"issuer", a.Issuer, next.Issuer},
+			[3]string{"date", a.Date, next.Date},
+			[3]string{"description", a.Description, next.Description},
+		)
+		steps = append(steps, newStep("Award", ja.Title, true, fields, func(database *db.DB) error {
+			return database.UpdateAward(next)
+		}))
+	}
+	return steps, nil
+}
+
+func planPublicationImport(database *db.DB, r *jsonResume) ([]importStep, error) {
+	existing, err := database.GetAllPublications()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, jp := range r.Publications {
+		i := slices.IndexFunc(existing, func(p models.Publication) bool {
+			return strings.EqualFold(p.Title, jp.Name)
+		})
+		next := models.Publication{
+			Title:       jp.Name,
+			Kind:        jp.Kind,
+			Venue:       jp.Publisher,
+			Date:        jp.ReleaseDate,
+			URL:         jp.URL,
+			Description: jp.Summary,
+		}
+		if i < 0 {
+			steps = append(steps, newStep("Publication", jp.Name, false, nil, func(database *db.DB) error {
+				_, err := database.CreatePublication(next)
+				return err
+			}))
+			continue
+		}
+
+		p := existing[i]
+		next.ID = p.ID
+		fields := diffFields(
+			[3]string{"title", p.Title, next.Title},
+			[3]string{"kind", p.Kind, next.Kind},
+			[3]string{"venue", p.Venue, next.Venue},
+			[3]string{"date", p.Date, next.Date},
+			[3]string{"URL", p.URL, next.URL},
+			[3]string{"description", p.Description, next.Description},
+		)
+		steps = append(steps, newStep("Publication", jp.Name, true, fields, func(database *db.DB) error {
+			return database.UpdatePublication(next)
+		}))
+	}
+	return steps, nil
+}
+
+// knownSkill returns a check for whether a keyword names a skill, either
+// one already stored, by name or alias, or one the file itself imports.
+// The ID is that of the stored skill, or 0 for one only the file has.
+func knownSkill(database *db.DB, r *jsonResume) (func(string) (int64, bool), error) {
+	skills, err := database.GetAllSkillsWithAliases()
+	if err != nil {
+		return nil, err
+	}
+	return func(name string) (int64, bool) {
+		if i := models.FindSkill(skills, name); i >= 0 {
+			return skills[i].ID, true
+		}
+		return 0, slices.ContainsFunc(r.Skills, func(s jsonSkill) bool {
+			return models.NormalizeSkillName(s.Name) == models.NormalizeSkillName(name)
+		})
+	}, nil
+}
+
+// missingSkill reports whether a keyword names a known skill that is not
+// yet linked. Keywords naming no skill are ignored rather than reported
+// as a change on every import.
+func missingSkill(linked []models.Skill, keywords []string, known func(string) (int64, bool)) bool {
+	for _, k := range keywords {
+		id, ok := known(k)
+		if ok && !slices.ContainsFunc(linked, func(s models.Skill) bool {
+			return s.ID == id
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// skillIDsByName looks up the named skills by name or alias; names with
+// no matching skill are skipped.
+func skillIDsByName(database *db.DB, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	skills, err := database.GetAllSkillsWithAliases()
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, name := range names {
+		i := models.FindSkill(skills, name)
+		if i >= 0 && !slices.Contains(ids, skills[i].ID) {
+			ids = append(ids, skills[i].ID)
+		}
+	}
+	return ids, nil
+}
+
+// linkProjectSkills links the named skills to a project. Names with no
+// matching skill are skipped; existing links are kept.
+func linkProjectSkills(database *db.DB, projectID int64, names []string) error {
+	skillIDs, err := skillIDsByName(database, names)
+	if err != nil {
+		return err
+	}
+	for _, id := range skillIDs {
+		if err := database.AddSkillToProject(id, projectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
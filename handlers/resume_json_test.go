@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+)
+
+// exportJSON builds the JSON Resume export the way HandleResumeJSON
+// does, with a fixed generation time so two exports can be compared.
+func exportJSON(t *testing.T, database *db.DB) string {
+	t.Helper()
+	resume, err := loadResume(database, nil, models.SurfacePDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resume.Generated = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	profile, err := database.GetProfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.MarshalIndent(toJSONResume(resume, profile), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func planExport(t *testing.T, database *db.DB, export string) []importStep {
+	t.Helper()
+	r, err := parseJSONResume([]byte(export))
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err := planImport(database, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return steps
+}
+
+// TestJSONResumeRoundTrip checks that importing an export changes
+// nothing, and that an export imported into an empty database exports
+// the same again.
+func TestJSONResumeRoundTrip(t *testing.T) {
+	seeded := db.New(filepath.Join(t.TempDir(), "seeded.db"))
+	defer seeded.Conn.Close()
+	seeded.Seed()
+	export := exportJSON(t, seeded)
+
+	for _, s := range planExport(t, seeded, export) {
+		if s.Action != pages.ImportUnchanged {
+			t.Errorf("re-import: %s %q is %s %v, want unchanged",
+				s.Kind, s.Title, s.Action, s.Fields)
+		}
+	}
+
+	empty := db.New(filepath.Join(t.TempDir(), "empty.db"))
+	defer empty.Conn.Close()
+	steps := planExport(t, empty, export)
+	err := empty.WithTx(func(tx *db.DB) error {
+		for _, s := range steps {
+			if s.Apply == nil {
+				continue
+			}
+			if err := s.Apply(tx); err != nil {
+				t.Errorf("import %s %q: %v", s.Kind, s.Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := exportJSON(t, empty); got != export {
+		t.Errorf("export after import differs:\n--- got\n%s\n--- want\n%s", got, export)
+	}
+}
+
+// TestJSONResumeOptionalDates imports a file with year-only and missing
+// dates and a certificate without an issuer, all of which the JSON
+// Resume schema allows.
+func TestJSONResumeOptionalDates(t *testing.T) {
+	const file = `{
+		"basics": {"name": "Ada Example"},
+		"work": [
+			{"name": "Acme", "position": "Engineer", "startDate": "2019", "endDate": "2021-06-30"},
+			{"name": "Initech", "position": "Intern"}
+		],
+		"education": [{"institution": "State University", "studyType": "B.S.", "endDate": "2018"}],
+		"certificates": [{"name": "Cloud Practitioner"}],
+		"awards": [{"title": "Hackathon Winner"}],
+		"publications": [{"name": "Boring Billing", "releaseDate": "2023"}]
+	}`
+	r, err := parseJSONResume([]byte(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct{ field, got, want string }{
+		{"work[0].startDate", r.Work[0].StartDate, "2019-01"},
+		{"work[0].endDate", r.Work[0].EndDate, "2021-06"},
+		{"work[1].startDate", r.Work[1].StartDate, ""},
+		{"education[0].endDate", r.Education[0].EndDate, "2018-01"},
+		{"certificates[0].date", r.Certificates[0].Date, ""},
+		{"awards[0].date", r.Awards[0].Date, ""},
+		{"publications[0].releaseDate", r.Publications[0].ReleaseDate, "2023-01"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+
+	database := db.New(filepath.Join(t.TempDir(), "empty.db"))
+	defer database.Conn.Close()
+	steps, err := planImport(database, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = database.WithTx(func(tx *db.DB) error {
+		for _, s := range steps {
+			if s.Apply == nil {
+				continue
+			}
+			if err := s.Apply(tx); err != nil {
+				return fmt.Errorf("import %s %q: %w", s.Kind, s.Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONResumeBadDate(t *testing.T) {
+	for _, date := range []string{"June 2019", "19", "2019-13", "abcd"} {
+		file := `{"basics": {"name": "Ada"}, "awards": [{"title": "Prize", "date": "` + date + `"}]}`
+		if _, err := parseJSONResume([]byte(file)); err == nil {
+			t.Errorf("date %q was accepted", date)
+		}
+	}
+}
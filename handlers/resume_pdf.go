@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/fonts"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/go-pdf/fpdf"
+)
+
+// ResumeRenderer lays out a resume as a PDF. Each implementation is one
+// theme that can be picked with /resume/pdf?theme=<Name>. Text is set in
+// font, which is DefaultFont unless the admin picked another for the
+// theme.
+type ResumeRenderer interface {
+	Name() string
+	Label() string
+	DefaultFont() string
+	Render(resume *models.Resume, font fonts.Family) *fpdf.Fpdf
+}
+
+// resumeThemes lists the available themes in the order the admin panel
+// and resume page offer them.
+var resumeThemes = []ResumeRenderer{
+	classicTheme{},
+	modernTheme{},
+	compactTheme{},
+}
+
+// defaultResumeTheme is used until a default is picked in the admin panel.
+const defaultResumeTheme = "classic"
+
+func resumeTheme(name string) (ResumeRenderer, bool) {
+	for _, t := range resumeThemes {
+		if t.Name() == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// configuredResumeTheme returns the default theme set in the admin panel,
+// falling back to defaultResumeTheme if none is set or it no longer exists.
+func configuredResumeTheme(database *db.DB) string {
+	name, _ := database.GetSetting(db.SettingResumeTheme)
+	if _, ok := resumeTheme(name); !ok {
+		return defaultResumeTheme
+	}
+	return name
+}
+
+// configuredResumeFont returns the font family the admin picked for
+// theme, or the theme's own default.
+func configuredResumeFont(database *db.DB, theme ResumeRenderer) fonts.Family {
+	name, _ := database.GetSetting(db.SettingResumeFontPrefix + theme.Name())
+	if f, ok := fonts.Lookup(name); ok {
+		return f
+	}
+	f, _ := fonts.Lookup(theme.DefaultFont())
+	return f
+}
+
+func resumeThemeOptions(database *db.DB) []pages.ResumeThemeOption {
+	opts := make([]pages.ResumeThemeOption, len(resumeThemes))
+	for i, t := range resumeThemes {
+		opts[i] = pages.ResumeThemeOption{
+			Name:  t.Name(),
+			Label: t.Label(),
+			Font:  configuredResumeFont(database, t).Name,
+		}
+	}
+	return opts
+}
+
+func fontOptions() []pages.FontOption {
+	opts := make([]pages.FontOption, len(fonts.Families))
+	for i, f := range fonts.Families {
+		opts[i] = pages.FontOption{
+			Name: f.Name, Label: f.Label, Italic: f.Italic != "",
+		}
+	}
+	return opts
+}
+
+// resumePDF is a Letter-size document with one bundled font family
+// registered for all of its text.
+type resumePDF struct {
+	*fpdf.Fpdf
+	family string
+}
+
+// newResumePDF starts a resume document in font. It fills in the
+// document properties from d and adds a "Page X of Y" footer, so themes
+// must leave at least 12mm of bottom margin.
+func newResumePDF(d *models.Resume, font fonts.Family) *resumePDF {
+	pdf := &resumePDF{Fpdf: fpdf.New("P", "mm", "Letter", ""), family: font.Name}
+	font.Register(pdf.Fpdf)
+
+	pdf.SetTitle(d.Name+" - Resume", true)
+	pdf.SetAuthor(d.Name, true)
+	pdf.SetSubject(d.Headline, true)
+	pdf.SetKeywords(strings.Join(skillNames(d.Skills), ", "), true)
+	pdf.SetCreator(d.Name, true)
+	pdf.SetCreationDate(d.Generated)
+
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.font("", 7.5)
+		pdf.SetTextColor(140, 140, 140)
+		pdf.CellFormat(
+			0, 4, "Generated "+d.Generated.Format("January 2, 2006"),
+			"", 0, "L", false, 0, "",
+		)
+		pdf.SetY(-10)
+		pdf.CellFormat(
+			0, 4, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()),
+			"", 0, "R", false, 0, "",
+		)
+		pdf.SetTextColor(0, 0, 0)
+	})
+	return pdf
+}
+
+// font switches to style ("", "B" or "I") and size of the document's
+// family.
+func (p *resumePDF) font(style string, size float64) {
+	p.SetFont(p.family, style, size)
+}
+
+// contactLine writes links on one line joined by sep, each one a
+// clickable link annotation where it has a URL. align is "L", "C" or "R"
+// within the current margins.
+func (p *resumePDF) contactLine(links []models.ContactLink, sep string, h float64, align string) {
+	if len(links) == 0 {
+		p.Ln(h)
+		return
+	}
+	width := p.GetStringWidth(sep) * float64(len(links)-1)
+	for _, l := range links {
+		width += p.GetStringWidth(l.Text)
+	}
+
+	pageW, _ := p.GetPageSize()
+	ml, _, mr, _ := p.GetMargins()
+	switch align {
+	case "C":
+		p.SetX(ml + (pageW-ml-mr-width)/2)
+	case "R":
+		p.SetX(pageW - mr - width)
+	}
+
+	for i, l := range links {
+		if i > 0 {
+			p.CellFormat(p.GetStringWidth(sep), h, sep, "", 0, "L", false, 0, "")
+		}
+		p.CellFormat(p.GetStringWidth(l.Text), h, l.Text, "", 0, "L", false, 0, l.URL)
+	}
+	p.Ln(h)
+}
+
+// bullets writes items as a bulleted list with hanging indents, in the
+// current font.
+func (p *resumePDF) bullets(items []string, h float64) {
+	ml, _, _, _ := p.GetMargins()
+	indent := p.GetStringWidth("•") + 2.5
+	for _, item := range items {
+		p.SetX(ml)
+		p.CellFormat(indent, h, "•", "", 0, "L", false, 0, "")
+		p.SetLeftMargin(ml + indent)
+		p.MultiCell(0, h, item, "", "L", false)
+		p.SetLeftMargin(ml)
+	}
+}
+
+// experienceCompany joins an experience's company with its location and
+// employment type, e.g. "Acme · Remote · Contract".
+func experienceCompany(exp models.Experience) string {
+	if sub := exp.Subtitle(); sub != "" {
+		return exp.Company + " · " + sub
+	}
+	return exp.Company
+}
+
+// experienceDates formats an experience's range as "2021-09  -  Present".
+func experienceDates(exp models.Experience) string {
+	endDate := exp.EndDate
+	if endDate == "" {
+		endDate = "Present"
+	} else if len(endDate) >= 7 {
+		endDate = endDate[:7]
+	}
+	startDate := exp.StartDate
+	if len(startDate) >= 7 {
+		startDate = startDate[:7]
+	}
+	return startDate + "  -  " + endDate
+}
+
+// educationStatus formats the dates and GPA, e.g.
+// "2021-09  -  Expected 2025-05  |  GPA: 3.29". Without dates an
+// in-progress degree reads "In Progress". It is empty when nothing
+// applies.
+func educationStatus(edu models.Education) string {
+	var parts []string
+	if dates := edu.Dates(); dates != "" {
+		parts = append(parts, dates)
+	} else if edu.In_progress {
+		parts = append(parts, "In Progress")
+	}
+	if edu.Gpa > 0 {
+		parts = append(parts, fmt.Sprintf("GPA: %.2f", edu.Gpa))
+	}
+	return strings.Join(parts, "  |  ")
+}
+
+// educationDetails lists the lines shown under a degree: field of study,
+// honors, coursework and class projects, each only when set.
+func educationDetails(edu models.Education) []string {
+	var lines []string
+	if field := edu.FieldOfStudy(); field != "" {
+		lines = append(lines, field)
+	}
+	if edu.Honors != "" {
+		lines = append(lines, "Honors: "+edu.Honors)
+	}
+	if len(edu.Coursework) > 0 {
+		lines = append(lines, "Coursework: "+strings.Join(edu.Coursework, ", "))
+	}
+	if len(edu.Projects) > 0 {
+		titles := make([]string, len(edu.Projects))
+		for i, p := range edu.Projects {
+			titles[i] = p.Title
+		}
+		lines = append(lines, "Projects: "+strings.Join(titles, ", "))
+	}
+	return lines
+}
+
+// certificationIssuer joins the issuer with the credential ID, e.g.
+// "Amazon Web Services · ID ABC-123".
+func certificationIssuer(cert models.Certification) string {
+	if cert.CredentialID != "" {
+		return cert.Issuer + " · ID " + cert.CredentialID
+	}
+	return cert.Issuer
+}
+
+// certificationDates formats the validity as "2023-04  -  2026-04", or
+// just the issue date when it never expires.
+func certificationDates(cert models.Certification) string {
+	if cert.ExpiryDate == "" {
+		return cert.IssuedDate
+	}
+	return cert.IssuedDate + "  -  " + cert.ExpiryDate
+}
+
+// publicationVenue labels the kind and venue, e.g. "Talk · GopherCon".
+func publicationVenue(pub models.Publication) string {
+	label := models.PublicationLabel(pub.Kind)
+	if pub.Venue != "" {
+		return label + " · " + pub.Venue
+	}
+	return label
+}
+
+// projectLinks returns a project's repo and live URLs as links.
+func projectLinks(p models.Project) []models.ContactLink {
+	var links []models.ContactLink
+	for _, u := range []string{p.RepoURL, p.LiveURL} {
+		if u != "" {
+			links = append(links, models.ContactLink{Text: models.ShortURL(u), URL: u})
+		}
+	}
+	return links
+}
+
+func skillNames(skills []models.Skill) []string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// drawLine rules a light line across the current margins.
+func drawLine(pdf *resumePDF) {
+	w, _ := pdf.GetPageSize()
+	ml, _, mr, _ := pdf.GetMargins()
+	y := pdf.GetY()
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.Line(ml, y, w-mr, y)
+	pdf.Ln(3)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DYankee/resume2/fonts"
+	"github.com/DYankee/resume2/models"
+)
+
+// TestResumePDFUnicode checks that non-ASCII text comes through every
+// font family, in regular, bold and italic text.
+func TestResumePDFUnicode(t *testing.T) {
+	const text = "Zoë — “quoted” 日本語"
+	resume := testResume()
+	resume.Experiences = []models.Experience{{
+		Title:       text,
+		Company:     "Café Ünïcode",
+		StartDate:   "2020-01",
+		Description: text,
+		Skills:      []models.Skill{{Name: text}},
+	}}
+
+	for _, font := range fonts.Families {
+		for _, theme := range resumeThemes {
+			t.Run(font.Name+"/"+theme.Name(), func(t *testing.T) {
+				got := pdfText(t, theme.Render(resume, font))
+				if n := strings.Count(got, text); n < 3 {
+					t.Errorf("%q appears %d times, want at least 3 (title, description, skills):\n%s", text, n, got)
+				}
+				if !strings.Contains(got, "Café Ünïcode") {
+					t.Errorf("company missing from PDF text:\n%s", got)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,589 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DYankee/resume2/models"
+)
+
+// ── Text exports ──────────────────────────────────
+//
+// Markdown, plain text and LaTeX renderings of the same models.Resume the
+// PDF themes draw, for recruiter portals and ATS systems that want
+// something simpler than a PDF. Each honors the resume's section order.
+
+// skillGroup is the skills of one category, in resume order.
+type skillGroup struct {
+	Category string
+	Skills   []string
+}
+
+// skillsByCategory groups skills by category, categories in order of
+// first appearance.
+func skillsByCategory(skills []models.Skill) []skillGroup {
+	var groups []skillGroup
+	index := make(map[string]int)
+	for _, s := range skills {
+		i, ok := index[s.Category]
+		if !ok {
+			i = len(groups)
+			index[s.Category] = i
+			groups = append(groups, skillGroup{Category: s.Category})
+		}
+		groups[i].Skills = append(groups[i].Skills, s.Name)
+	}
+	return groups
+}
+
+// ── Markdown ──────────────────────────────────────
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`#`, `\#`, `<`, `\<`, `>`, `\>`, `|`, `\|`,
+)
+
+func mdLink(l models.ContactLink) string {
+	if l.URL == "" {
+		return mdEscaper.Replace(l.Text)
+	}
+	return "[" + mdEscaper.Replace(l.Text) + "](" + l.URL + ")"
+}
+
+func resumeMarkdown(d *models.Resume) ([]byte, error) {
+	var b strings.Builder
+	md := mdEscaper.Replace
+
+	fmt.Fprintf(&b, "# %s\n\n", md(d.Name))
+	if d.Headline != "" {
+		fmt.Fprintf(&b, "**%s**\n\n", md(d.Headline))
+	}
+	if len(d.Contact) > 0 {
+		links := make([]string, len(d.Contact))
+		for i, l := range d.Contact {
+			links[i] = mdLink(l)
+		}
+		b.WriteString(strings.Join(links, " · ") + "\n\n")
+	}
+
+	sections := map[string]func(){
+		models.SectionAbout: func() {
+			if len(d.About) == 0 {
+				return
+			}
+			b.WriteString("## About\n\n")
+			for _, p := range d.About {
+				b.WriteString(md(p) + "\n\n")
+			}
+		},
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			b.WriteString("## Education\n\n")
+			for _, edu := range d.Education {
+				fmt.Fprintf(&b, "- **%s**, %s", md(edu.Degree), md(edu.College))
+				if status := educationStatus(edu); status != "" {
+					fmt.Fprintf(&b, " (%s)", strings.ReplaceAll(status, "  |  ", ", "))
+				}
+				b.WriteString("\n")
+				for _, line := range educationDetails(edu) {
+					b.WriteString("  - " + md(line) + "\n")
+				}
+			}
+			b.WriteString("\n")
+		},
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			b.WriteString("## Experience\n\n")
+			for _, exp := range d.Experiences {
+				fmt.Fprintf(&b, "### %s, %s\n\n", md(exp.Title), md(exp.Company))
+				meta := experienceDates(exp)
+				if sub := exp.Subtitle(); sub != "" {
+					meta = sub + " · " + meta
+				}
+				fmt.Fprintf(&b, "*%s*\n\n", md(meta))
+				if exp.Description != "" {
+					b.WriteString(md(exp.Description) + "\n\n")
+				}
+				if len(exp.Highlights) > 0 {
+					for _, h := range exp.Highlights {
+						b.WriteString("- " + md(h) + "\n")
+					}
+					b.WriteString("\n")
+				}
+				if len(exp.Skills) > 0 {
+					fmt.Fprintf(&b, "*%s*\n\n", md(strings.Join(skillNames(exp.Skills), ", ")))
+				}
+			}
+		},
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			b.WriteString("## Projects\n\n")
+			for _, pw := range d.Projects {
+				fmt.Fprintf(&b, "### %s\n\n", md(pw.Project.Title))
+				if links := projectLinks(pw.Project); len(links) > 0 {
+					parts := make([]string, len(links))
+					for i, l := range links {
+						parts[i] = mdLink(l)
+					}
+					b.WriteString(strings.Join(parts, " · ") + "\n\n")
+				}
+				if pw.Project.Description != "" {
+					b.WriteString(md(pw.Project.Description) + "\n\n")
+				}
+				if len(pw.Skills) > 0 {
+					fmt.Fprintf(&b, "*%s*\n\n", md(strings.Join(skillNames(pw.Skills), ", ")))
+				}
+			}
+		},
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			b.WriteString("## Skills\n\n")
+			for _, g := range skillsByCategory(d.Skills) {
+				fmt.Fprintf(&b, "- **%s:** %s\n", md(g.Category), md(strings.Join(g.Skills, ", ")))
+			}
+			b.WriteString("\n")
+		},
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			b.WriteString("## Certifications\n\n")
+			for _, cert := range d.Certifications {
+				name := mdLink(models.ContactLink{Text: cert.Name, URL: cert.CredentialURL})
+				fmt.Fprintf(&b, "- **%s**, %s (%s)\n", name, md(certificationIssuer(cert)),
+					md(strings.ReplaceAll(certificationDates(cert), "  -  ", " – ")))
+			}
+			b.WriteString("\n")
+		},
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			b.WriteString("## Awards\n\n")
+			for _, award := range d.Awards {
+				fmt.Fprintf(&b, "- **%s**", md(award.Title))
+				if award.Issuer != "" {
+					fmt.Fprintf(&b, ", %s", md(award.Issuer))
+				}
+				fmt.Fprintf(&b, " (%s)\n", md(award.Date))
+				if award.Description != "" {
+					b.WriteString("  - " + md(award.Description) + "\n")
+				}
+			}
+			b.WriteString("\n")
+		},
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			b.WriteString("## Publications & Talks\n\n")
+			for _, pub := range d.Publications {
+				title := mdLink(models.ContactLink{Text: pub.Title, URL: pub.URL})
+				fmt.Fprintf(&b, "- **%s**, %s (%s)\n", title, md(publicationVenue(pub)), md(pub.Date))
+				if pub.Description != "" {
+					b.WriteString("  - " + md(pub.Description) + "\n")
+				}
+			}
+			b.WriteString("\n")
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paras := cs.Paragraphs()
+			if len(paras) == 0 {
+				return
+			}
+			fmt.Fprintf(&b, "## %s\n\n", md(cs.Title))
+			for _, p := range paras {
+				b.WriteString(md(p) + "\n\n")
+			}
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// ── Plain text ────────────────────────────────────
+
+// resumeText is deliberately bare: no markup, no columns, full URLs, so
+// it survives being pasted into any form field.
+func resumeText(d *models.Resume) ([]byte, error) {
+	var b strings.Builder
+
+	heading := func(title string) {
+		title = strings.ToUpper(title)
+		b.WriteString(title + "\n" + strings.Repeat("=", len(title)) + "\n\n")
+	}
+
+	b.WriteString(d.Name + "\n")
+	if d.Headline != "" {
+		b.WriteString(d.Headline + "\n")
+	}
+	for _, l := range d.Contact {
+		if l.URL != "" && !strings.HasPrefix(l.URL, "mailto:") && !strings.HasPrefix(l.URL, "tel:") {
+			b.WriteString(l.URL + "\n")
+		} else {
+			b.WriteString(l.Text + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	sections := map[string]func(){
+		models.SectionAbout: func() {
+			if len(d.About) == 0 {
+				return
+			}
+			heading("About")
+			for _, p := range d.About {
+				b.WriteString(p + "\n\n")
+			}
+		},
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			heading("Education")
+			for _, edu := range d.Education {
+				b.WriteString(edu.Degree + "\n" + edu.College + "\n")
+				if status := educationStatus(edu); status != "" {
+					b.WriteString(status + "\n")
+				}
+				for _, line := range educationDetails(edu) {
+					b.WriteString(line + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			heading("Experience")
+			for _, exp := range d.Experiences {
+				b.WriteString(exp.Title + "\n" + experienceCompany(exp) + "\n")
+				b.WriteString(experienceDates(exp) + "\n")
+				if exp.Description != "" {
+					b.WriteString(exp.Description + "\n")
+				}
+				for _, h := range exp.Highlights {
+					b.WriteString("  * " + h + "\n")
+				}
+				if len(exp.Skills) > 0 {
+					b.WriteString("Skills: " + strings.Join(skillNames(exp.Skills), ", ") + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			heading("Projects")
+			for _, pw := range d.Projects {
+				b.WriteString(pw.Project.Title + "\n")
+				for _, l := range projectLinks(pw.Project) {
+					b.WriteString(l.URL + "\n")
+				}
+				if pw.Project.Description != "" {
+					b.WriteString(pw.Project.Description + "\n")
+				}
+				if len(pw.Skills) > 0 {
+					b.WriteString("Skills: " + strings.Join(skillNames(pw.Skills), ", ") + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			heading("Skills")
+			for _, g := range skillsByCategory(d.Skills) {
+				b.WriteString(g.Category + ": " + strings.Join(g.Skills, ", ") + "\n")
+			}
+			b.WriteString("\n")
+		},
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			heading("Certifications")
+			for _, cert := range d.Certifications {
+				b.WriteString(cert.Name + "\n" + certificationIssuer(cert) + "\n")
+				b.WriteString(certificationDates(cert) + "\n")
+				if cert.CredentialURL != "" {
+					b.WriteString(cert.CredentialURL + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			heading("Awards")
+			for _, award := range d.Awards {
+				b.WriteString(award.Title + "\n")
+				if award.Issuer != "" {
+					b.WriteString(award.Issuer + "\n")
+				}
+				b.WriteString(award.Date + "\n")
+				if award.Description != "" {
+					b.WriteString(award.Description + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			heading("Publications & Talks")
+			for _, pub := range d.Publications {
+				b.WriteString(pub.Title + "\n" + publicationVenue(pub) + "\n")
+				b.WriteString(pub.Date + "\n")
+				if pub.URL != "" {
+					b.WriteString(pub.URL + "\n")
+				}
+				if pub.Description != "" {
+					b.WriteString(pub.Description + "\n")
+				}
+				b.WriteString("\n")
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paras := cs.Paragraphs()
+			if len(paras) == 0 {
+				return
+			}
+			heading(cs.Title)
+			for _, p := range paras {
+				b.WriteString(p + "\n\n")
+			}
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// ── LaTeX ─────────────────────────────────────────
+
+var texEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, `&`, `\&`, `%`, `\%`, `$`, `\$`, `#`, `\#`,
+	`_`, `\_`, `{`, `\{`, `}`, `\}`, `~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func texLink(l models.ContactLink) string {
+	if l.URL == "" {
+		return texEscaper.Replace(l.Text)
+	}
+	return `\href{` + texURL(l.URL) + `}{` + texEscaper.Replace(l.Text) + `}`
+}
+
+// texURL escapes the characters \href still treats specially.
+func texURL(u string) string {
+	return strings.NewReplacer(`\`, `\\`, `#`, `\#`, `%`, `\%`, `{`, `\{`, `}`, `\}`).Replace(u)
+}
+
+// resumeTeX writes a standalone article that builds with pdflatex or
+// xelatex and no packages beyond a standard TeX Live install.
+func resumeTeX(d *models.Resume) ([]byte, error) {
+	var b strings.Builder
+	tex := texEscaper.Replace
+
+	b.WriteString(`\documentclass[11pt]{article}
+\usepackage[T1]{fontenc}
+\usepackage[utf8]{inputenc}
+\usepackage[margin=0.75in]{geometry}
+\usepackage[hidelinks]{hyperref}
+\usepackage{enumitem}
+\setlength{\parindent}{0pt}
+\pagestyle{empty}
+`)
+	fmt.Fprintf(&b, "\\hypersetup{pdftitle={%s - Resume}, pdfauthor={%s}}\n\n", tex(d.Name), tex(d.Name))
+	b.WriteString("\\begin{document}\n\n")
+
+	b.WriteString("\\begin{center}\n")
+	fmt.Fprintf(&b, "  {\\LARGE\\bfseries %s}\\\\[2pt]\n", tex(d.Name))
+	if d.Headline != "" {
+		fmt.Fprintf(&b, "  %s\\\\[2pt]\n", tex(d.Headline))
+	}
+	if len(d.Contact) > 0 {
+		links := make([]string, len(d.Contact))
+		for i, l := range d.Contact {
+			links[i] = texLink(l)
+		}
+		fmt.Fprintf(&b, "  \\small %s\n", strings.Join(links, ` \,|\, `))
+	}
+	b.WriteString("\\end{center}\n\n")
+
+	sections := map[string]func(){
+		models.SectionAbout: func() {
+			if len(d.About) == 0 {
+				return
+			}
+			b.WriteString("\\section*{About}\n")
+			for _, p := range d.About {
+				b.WriteString(tex(p) + "\n\n")
+			}
+		},
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Education}\n")
+			for _, edu := range d.Education {
+				fmt.Fprintf(&b, "\\textbf{%s} \\hfill %s\\\\\n", tex(edu.Degree), tex(educationStatus(edu)))
+				fmt.Fprintf(&b, "%s\n", tex(edu.College))
+				for _, line := range educationDetails(edu) {
+					fmt.Fprintf(&b, "\\\\\n{\\small %s}\n", tex(line))
+				}
+				b.WriteString("\n")
+			}
+		},
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Experience}\n")
+			for _, exp := range d.Experiences {
+				fmt.Fprintf(&b, "\\textbf{%s} \\hfill %s\\\\\n", tex(exp.Title), tex(experienceDates(exp)))
+				fmt.Fprintf(&b, "\\textit{%s}\n", tex(experienceCompany(exp)))
+				if exp.Description != "" {
+					fmt.Fprintf(&b, "\\\\[2pt]\n%s\n", tex(exp.Description))
+				}
+				if len(exp.Highlights) > 0 {
+					b.WriteString("\\begin{itemize}[leftmargin=*, nosep]\n")
+					for _, h := range exp.Highlights {
+						fmt.Fprintf(&b, "  \\item %s\n", tex(h))
+					}
+					b.WriteString("\\end{itemize}\n")
+				}
+				if len(exp.Skills) > 0 {
+					if len(exp.Highlights) == 0 {
+						b.WriteString("\\\\\n")
+					}
+					fmt.Fprintf(&b, "\\textit{\\small %s}\n", tex(strings.Join(skillNames(exp.Skills), ", ")))
+				}
+				b.WriteString("\n\\medskip\n\n")
+			}
+		},
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Projects}\n")
+			for _, pw := range d.Projects {
+				fmt.Fprintf(&b, "\\textbf{%s}", tex(pw.Project.Title))
+				if links := projectLinks(pw.Project); len(links) > 0 {
+					parts := make([]string, len(links))
+					for i, l := range links {
+						parts[i] = texLink(l)
+					}
+					fmt.Fprintf(&b, " \\hfill \\small %s", strings.Join(parts, ` \,|\, `))
+				}
+				b.WriteString("\n")
+				if pw.Project.Description != "" {
+					fmt.Fprintf(&b, "\\\\[2pt]\n%s\n", tex(pw.Project.Description))
+				}
+				if len(pw.Skills) > 0 {
+					fmt.Fprintf(&b, "\\\\\n\\textit{\\small %s}\n", tex(strings.Join(skillNames(pw.Skills), ", ")))
+				}
+				b.WriteString("\n\\medskip\n\n")
+			}
+		},
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Skills}\n")
+			b.WriteString("\\begin{itemize}[leftmargin=*, nosep]\n")
+			for _, g := range skillsByCategory(d.Skills) {
+				fmt.Fprintf(&b, "  \\item \\textbf{%s:} %s\n", tex(g.Category), tex(strings.Join(g.Skills, ", ")))
+			}
+			b.WriteString("\\end{itemize}\n\n")
+		},
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Certifications}\n")
+			for _, cert := range d.Certifications {
+				name := texLink(models.ContactLink{Text: cert.Name, URL: cert.CredentialURL})
+				texEntry(&b, name, certificationDates(cert), certificationIssuer(cert), "")
+			}
+		},
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Awards}\n")
+			for _, award := range d.Awards {
+				texEntry(&b, tex(award.Title), award.Date, award.Issuer, award.Description)
+			}
+		},
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			b.WriteString("\\section*{Publications \\& Talks}\n")
+			for _, pub := range d.Publications {
+				title := texLink(models.ContactLink{Text: pub.Title, URL: pub.URL})
+				texEntry(&b, title, pub.Date, publicationVenue(pub), pub.Description)
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paras := cs.Paragraphs()
+			if len(paras) == 0 {
+				return
+			}
+			fmt.Fprintf(&b, "\\section*{%s}\n", tex(cs.Title))
+			for _, p := range paras {
+				b.WriteString(tex(p) + "\n\n")
+			}
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+
+	b.WriteString("\\end{document}\n")
+	return []byte(b.String()), nil
+}
+
+// texEntry writes a bold title (already escaped) with the date on the
+// right, then an optional italic subtitle and description.
+func texEntry(b *strings.Builder, title, date, subtitle, desc string) {
+	tex := texEscaper.Replace
+	fmt.Fprintf(b, "\\textbf{%s} \\hfill %s\n", title, tex(date))
+	if subtitle != "" {
+		fmt.Fprintf(b, "\\\\\n\\textit{%s}\n", tex(subtitle))
+	}
+	if desc != "" {
+		fmt.Fprintf(b, "\\\\[2pt]\n%s\n", tex(desc))
+	}
+	b.WriteString("\n\\smallskip\n\n")
+}
@@ -0,0 +1,764 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/DYankee/resume2/fonts"
+	"github.com/DYankee/resume2/models"
+	"github.com/go-pdf/fpdf"
+)
+
+// ── Classic ───────────────────────────────────────
+//
+// Single column with a centered header, in the order
+// About → Education → Experience → Projects → Skills → Certifications →
+// Awards → Publications, unless the admin arranged the PDF sections.
+
+type classicTheme struct{}
+
+func (classicTheme) Name() string        { return "classic" }
+func (classicTheme) Label() string       { return "Classic (single column)" }
+func (classicTheme) DefaultFont() string { return fonts.Serif }
+
+func (classicTheme) Render(d *models.Resume, font fonts.Family) *fpdf.Fpdf {
+	pdf := newResumePDF(d, font)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	// ── Header ───────────────────────────────────────────────
+	pdf.font("B", 22)
+	pdf.CellFormat(0, 10, d.Name, "", 1, "C", false, 0, "")
+
+	pdf.font("", 12)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 7, d.Headline, "", 1, "C", false, 0, "")
+
+	pdf.font("", 9)
+	pdf.contactLine(d.Contact, "  |  ", 5, "C")
+	pdf.SetTextColor(0, 0, 0)
+
+	drawLine(pdf)
+
+	paragraphs := func(title string, paras []string) {
+		if len(paras) == 0 {
+			return
+		}
+		classicHeading(pdf, title)
+		pdf.font("", 10)
+		for i, p := range paras {
+			if i > 0 {
+				pdf.Ln(2)
+			}
+			pdf.MultiCell(0, 5, p, "", "L", false)
+		}
+	}
+
+	sections := map[string]func(){
+		// ── About ────────────────────────────────────────────────
+		models.SectionAbout: func() {
+			paragraphs("About", d.About)
+		},
+
+		// ── Education ────────────────────────────────────────────
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			classicHeading(pdf, "Education")
+			for _, edu := range d.Education {
+				pdf.font("B", 11)
+				degreeW := pdf.GetStringWidth(edu.Degree) + 2
+				pdf.CellFormat(degreeW, 6, edu.Degree, "", 0, "L", false, 0, "")
+
+				if status := educationStatus(edu); status != "" {
+					pdf.font("", 9)
+					pdf.CellFormat(0, 6, status, "", 0, "R", false, 0, "")
+				}
+				pdf.Ln(6)
+
+				pdf.font("", 10)
+				pdf.SetTextColor(80, 80, 80)
+				pdf.CellFormat(0, 5, edu.College, "", 1, "L", false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+				pdf.font("", 9)
+				for _, line := range educationDetails(edu) {
+					pdf.MultiCell(0, 4.5, line, "", "L", false)
+				}
+				pdf.Ln(2)
+			}
+		},
+
+		// ── Experience ───────────────────────────────────────────
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			classicHeading(pdf, "Experience")
+			for _, exp := range d.Experiences {
+				pdf.font("B", 11)
+				titleW := pdf.GetStringWidth(exp.Title) + 2
+				pdf.CellFormat(titleW, 6, exp.Title, "", 0, "L", false, 0, "")
+
+				pdf.font("", 9)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.CellFormat(0, 6, experienceDates(exp), "", 0, "R", false, 0, "")
+				pdf.Ln(6)
+
+				pdf.font("", 10)
+				pdf.SetTextColor(80, 80, 80)
+				pdf.CellFormat(0, 5, experienceCompany(exp), "", 1, "L", false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+
+				pdf.font("", 9)
+				if exp.Description != "" {
+					pdf.MultiCell(0, 4.5, exp.Description, "", "L", false)
+				}
+				pdf.bullets(exp.Highlights, 4.5)
+				if len(exp.Skills) > 0 {
+					pdf.font("I", 8.5)
+					pdf.SetTextColor(80, 80, 80)
+					pdf.MultiCell(
+						0, 4.5, strings.Join(skillNames(exp.Skills), ", "),
+						"", "L", false,
+					)
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(3)
+			}
+		},
+
+		// ── Projects ─────────────────────────────────────────────
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			classicHeading(pdf, "Projects")
+			for _, pw := range d.Projects {
+				pdf.font("B", 11)
+				titleW := pdf.GetStringWidth(pw.Project.Title) + 2
+				pdf.CellFormat(titleW, 6, pw.Project.Title, "", 0, "L", false, 0, "")
+
+				pdf.font("", 9)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.contactLine(projectLinks(pw.Project), "  |  ", 6, "R")
+				pdf.SetTextColor(0, 0, 0)
+
+				if pw.Project.Description != "" {
+					pdf.font("", 9)
+					pdf.MultiCell(0, 4.5, pw.Project.Description, "", "L", false)
+				}
+				if len(pw.Skills) > 0 {
+					pdf.font("I", 8.5)
+					pdf.SetTextColor(80, 80, 80)
+					pdf.MultiCell(
+						0, 4.5, strings.Join(skillNames(pw.Skills), ", "),
+						"", "L", false,
+					)
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(3)
+			}
+		},
+
+		// ── Skills ───────────────────────────────────────────────
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			classicHeading(pdf, "Skills")
+			pdf.font("", 10)
+			pdf.MultiCell(
+				0, 5, strings.Join(skillNames(d.Skills), "  |  "),
+				"", "L", false,
+			)
+		},
+
+		// ── Certifications ───────────────────────────────────────
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			classicHeading(pdf, "Certifications")
+			for _, cert := range d.Certifications {
+				classicEntry(pdf, cert.Name, certificationDates(cert), certificationIssuer(cert), "")
+			}
+		},
+
+		// ── Awards ───────────────────────────────────────────────
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			classicHeading(pdf, "Awards")
+			for _, award := range d.Awards {
+				classicEntry(pdf, award.Title, award.Date, award.Issuer, award.Description)
+			}
+		},
+
+		// ── Publications ─────────────────────────────────────────
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			classicHeading(pdf, "Publications & Talks")
+			for _, pub := range d.Publications {
+				classicEntry(pdf, pub.Title, pub.Date, publicationVenue(pub), pub.Description)
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paragraphs(cs.Title, cs.Paragraphs())
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+
+	return pdf.Fpdf
+}
+
+// classicEntry writes a bold title with a date on the right, then an
+// optional grey subtitle and description.
+func classicEntry(pdf *resumePDF, title, date, subtitle, desc string) {
+	pdf.font("B", 10.5)
+	titleW := pdf.GetStringWidth(title) + 2
+	pdf.CellFormat(titleW, 5.5, title, "", 0, "L", false, 0, "")
+	pdf.font("", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5.5, date, "", 1, "R", false, 0, "")
+	if subtitle != "" {
+		pdf.SetTextColor(80, 80, 80)
+		pdf.CellFormat(0, 4.5, subtitle, "", 1, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	if desc != "" {
+		pdf.MultiCell(0, 4.5, desc, "", "L", false)
+	}
+	pdf.Ln(2)
+}
+
+func classicHeading(pdf *resumePDF, title string) {
+	pdf.Ln(4)
+	pdf.font("B", 13)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+	drawLine(pdf)
+}
+
+// ── Modern ────────────────────────────────────────
+//
+// Two columns: a shaded sidebar with contact details, skills, education
+// and certifications, and a main column with the other sections.
+
+type modernTheme struct{}
+
+const modernSidebarW = 65.0
+
+func (modernTheme) Name() string        { return "modern" }
+func (modernTheme) Label() string       { return "Modern (two column)" }
+func (modernTheme) DefaultFont() string { return fonts.SansCondensed }
+
+func (modernTheme) Render(d *models.Resume, font fonts.Family) *fpdf.Fpdf {
+	pdf := newResumePDF(d, font)
+	pageW, pageH := pdf.GetPageSize()
+
+	// Shade the sidebar on every page, including overflow pages
+	// of the main column.
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFillColor(241, 243, 246)
+		pdf.Rect(0, 0, modernSidebarW, pageH, "F")
+	})
+
+	// ── Sidebar ──────────────────────────────────────────────
+	// The sidebar is laid out first, on page one only.
+	pdf.SetMargins(8, 15, pageW-modernSidebarW+8)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	pdf.font("B", 18)
+	pdf.MultiCell(0, 8, d.Name, "", "L", false)
+	pdf.font("", 10)
+	pdf.SetTextColor(79, 70, 229)
+	pdf.MultiCell(0, 5, d.Headline, "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.Ln(3)
+	pdf.font("", 8.5)
+	for _, l := range d.Contact {
+		pdf.CellFormat(0, 4.5, l.Text, "", 1, "L", false, 0, l.URL)
+	}
+
+	sidebar := map[string]func(){
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			modernSidebarHeading(pdf, "Skills")
+			ml, _, mr, _ := pdf.GetMargins()
+			barW := pageW - ml - mr
+			for _, s := range d.Skills {
+				pdf.font("", 9)
+				pdf.CellFormat(0, 4.5, s.Name, "", 1, "L", false, 0, "")
+
+				y := pdf.GetY()
+				pdf.SetFillColor(215, 218, 224)
+				pdf.Rect(ml, y, barW, 1.2, "F")
+				pdf.SetFillColor(79, 70, 229)
+				pdf.Rect(ml, y, barW*float64(s.Proficiency)/100, 1.2, "F")
+				pdf.Ln(2.5)
+			}
+		},
+
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			modernSidebarHeading(pdf, "Education")
+			for _, edu := range d.Education {
+				pdf.font("B", 9)
+				pdf.MultiCell(0, 4.5, edu.Degree, "", "L", false)
+				pdf.font("", 8.5)
+				pdf.SetTextColor(80, 80, 80)
+				pdf.MultiCell(0, 4.5, edu.College, "", "L", false)
+				if status := educationStatus(edu); status != "" {
+					pdf.MultiCell(0, 4.5, status, "", "L", false)
+				}
+				pdf.font("", 8)
+				for _, line := range educationDetails(edu) {
+					pdf.MultiCell(0, 4, line, "", "L", false)
+				}
+				pdf.SetTextColor(0, 0, 0)
+				pdf.Ln(2)
+			}
+		},
+
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			modernSidebarHeading(pdf, "Certifications")
+			for _, cert := range d.Certifications {
+				pdf.font("B", 9)
+				pdf.MultiCell(0, 4.5, cert.Name, "", "L", false)
+				pdf.font("", 8.5)
+				pdf.SetTextColor(80, 80, 80)
+				pdf.MultiCell(0, 4.5, certificationIssuer(cert), "", "L", false)
+				pdf.MultiCell(0, 4.5, certificationDates(cert), "", "L", false)
+				pdf.SetTextColor(0, 0, 0)
+				pdf.Ln(2)
+			}
+		},
+	}
+	for _, name := range d.Sections {
+		if render, ok := sidebar[name]; ok {
+			render()
+		}
+	}
+
+	// ── Main column ──────────────────────────────────────────
+	pdf.SetMargins(modernSidebarW+10, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetXY(modernSidebarW+10, 15)
+
+	paragraphs := func(title string, paras []string) {
+		if len(paras) == 0 {
+			return
+		}
+		modernHeading(pdf, title)
+		pdf.font("", 10)
+		for i, p := range paras {
+			if i > 0 {
+				pdf.Ln(2)
+			}
+			pdf.MultiCell(0, 5, p, "", "L", false)
+		}
+	}
+
+	main := map[string]func(){
+		models.SectionAbout: func() {
+			paragraphs("About", d.About)
+		},
+
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			modernHeading(pdf, "Experience")
+			for _, exp := range d.Experiences {
+				pdf.font("B", 11)
+				pdf.MultiCell(0, 6, exp.Title, "", "L", false)
+
+				pdf.font("", 9)
+				pdf.SetTextColor(79, 70, 229)
+				company := experienceCompany(exp)
+				companyW := pdf.GetStringWidth(company) + 2
+				pdf.CellFormat(companyW, 5, company, "", 0, "L", false, 0, "")
+				pdf.SetTextColor(100, 100, 100)
+				pdf.CellFormat(0, 5, experienceDates(exp), "", 1, "R", false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+
+				if exp.Description != "" || len(exp.Highlights) > 0 {
+					pdf.Ln(1)
+				}
+				pdf.font("", 9)
+				if exp.Description != "" {
+					pdf.MultiCell(0, 4.5, exp.Description, "", "L", false)
+				}
+				pdf.bullets(exp.Highlights, 4.5)
+				if len(exp.Skills) > 0 {
+					pdf.font("", 8.5)
+					pdf.SetTextColor(79, 70, 229)
+					pdf.MultiCell(
+						0, 4.5, strings.Join(skillNames(exp.Skills), "  ·  "),
+						"", "L", false,
+					)
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(4)
+			}
+		},
+
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			modernHeading(pdf, "Projects")
+			for _, pw := range d.Projects {
+				pdf.font("B", 11)
+				pdf.MultiCell(0, 6, pw.Project.Title, "", "L", false)
+
+				if links := projectLinks(pw.Project); len(links) > 0 {
+					pdf.font("", 9)
+					pdf.SetTextColor(79, 70, 229)
+					pdf.contactLine(links, "  |  ", 5, "L")
+					pdf.SetTextColor(0, 0, 0)
+				}
+				if pw.Project.Description != "" {
+					pdf.Ln(1)
+					pdf.font("", 9)
+					pdf.MultiCell(0, 4.5, pw.Project.Description, "", "L", false)
+				}
+				if len(pw.Skills) > 0 {
+					pdf.font("I", 8.5)
+					pdf.SetTextColor(100, 100, 100)
+					pdf.MultiCell(
+						0, 4.5, strings.Join(skillNames(pw.Skills), "  ·  "),
+						"", "L", false,
+					)
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(4)
+			}
+		},
+
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			modernHeading(pdf, "Awards")
+			for _, award := range d.Awards {
+				modernEntry(pdf, award.Title, award.Issuer, award.Date, award.Description)
+			}
+		},
+
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			modernHeading(pdf, "Publications & Talks")
+			for _, pub := range d.Publications {
+				modernEntry(pdf, pub.Title, publicationVenue(pub), pub.Date, pub.Description)
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		main[cs.Key()] = func() {
+			paragraphs(cs.Title, cs.Paragraphs())
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := main[name]; ok {
+			render()
+		}
+	}
+
+	return pdf.Fpdf
+}
+
+func modernSidebarHeading(pdf *resumePDF, title string) {
+	pdf.Ln(6)
+	pdf.font("B", 10)
+	pdf.SetTextColor(79, 70, 229)
+	pdf.CellFormat(0, 6, strings.ToUpper(title), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(1)
+}
+
+// modernEntry writes a bold title over an accented subtitle with the date
+// on the right, then an optional description.
+func modernEntry(pdf *resumePDF, title, subtitle, date, desc string) {
+	pdf.font("B", 10.5)
+	pdf.MultiCell(0, 5.5, title, "", "L", false)
+	pdf.font("", 9)
+	pdf.SetTextColor(79, 70, 229)
+	subtitleW := pdf.GetStringWidth(subtitle) + 2
+	pdf.CellFormat(subtitleW, 5, subtitle, "", 0, "L", false, 0, "")
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5, date, "", 1, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	if desc != "" {
+		pdf.MultiCell(0, 4.5, desc, "", "L", false)
+	}
+	pdf.Ln(3)
+}
+
+func modernHeading(pdf *resumePDF, title string) {
+	pdf.Ln(2)
+	pdf.font("B", 14)
+	pdf.SetTextColor(79, 70, 229)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	drawLine(pdf)
+}
+
+// ── Compact ───────────────────────────────────────
+//
+// Dense single column meant to fit on one page. If the content spills
+// over, the text is scaled down a step at a time until it fits.
+
+type compactTheme struct{}
+
+// compactSteps is how many times the layout is shrunk by 10% before
+// giving up on fitting a single page.
+const compactSteps = 3
+
+func (compactTheme) Name() string        { return "compact" }
+func (compactTheme) Label() string       { return "Compact (one page)" }
+func (compactTheme) DefaultFont() string { return fonts.SansCondensed }
+
+func (compactTheme) Render(d *models.Resume, font fonts.Family) *fpdf.Fpdf {
+	var pdf *fpdf.Fpdf
+	for step := 0; step <= compactSteps; step++ {
+		pdf = compactPDF(d, font, 1-0.1*float64(step))
+		if pdf.PageCount() == 1 {
+			break
+		}
+	}
+	return pdf
+}
+
+func compactPDF(d *models.Resume, font fonts.Family, scale float64) *fpdf.Fpdf {
+	pdf := newResumePDF(d, font)
+	pdf.SetMargins(12, 10, 12)
+	pdf.SetAutoPageBreak(true, 14)
+	pdf.AddPage()
+
+	lh := 4 * scale
+
+	// ── Header ───────────────────────────────────────────────
+	pdf.font("B", 16*scale)
+	nameW := pdf.GetStringWidth(d.Name) + 2
+	pdf.CellFormat(nameW, 7*scale, d.Name, "", 0, "L", false, 0, "")
+	pdf.font("", 8*scale)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.contactLine(d.Contact, "  |  ", 7*scale, "R")
+	pdf.font("", 9.5*scale)
+	pdf.CellFormat(0, 5*scale, d.Headline, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	drawLine(pdf)
+
+	sections := map[string]func(){
+		// Only the first About paragraph; the rest is for the long form.
+		models.SectionAbout: func() {
+			if len(d.About) == 0 {
+				return
+			}
+			pdf.font("", 8.5*scale)
+			pdf.MultiCell(0, lh, d.About[0], "", "L", false)
+		},
+
+		models.SectionExperience: func() {
+			if len(d.Experiences) == 0 {
+				return
+			}
+			compactHeading(pdf, "Experience", scale)
+			for _, exp := range d.Experiences {
+				pdf.font("B", 9*scale)
+				titleW := pdf.GetStringWidth(exp.Title) + 1
+				pdf.CellFormat(titleW, lh+0.5, exp.Title, "", 0, "L", false, 0, "")
+				pdf.font("", 9*scale)
+				company := ", " + experienceCompany(exp)
+				companyW := pdf.GetStringWidth(company) + 2
+				pdf.CellFormat(companyW, lh+0.5, company, "", 0, "L", false, 0, "")
+				pdf.font("", 8*scale)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.CellFormat(0, lh+0.5, experienceDates(exp), "", 1, "R", false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+
+				pdf.font("", 8*scale)
+				if exp.Description != "" {
+					pdf.MultiCell(0, lh-0.3, exp.Description, "", "L", false)
+				}
+				pdf.bullets(exp.Highlights, lh-0.3)
+				if len(exp.Skills) > 0 {
+					pdf.font("I", 7.5*scale)
+					pdf.SetTextColor(80, 80, 80)
+					pdf.MultiCell(
+						0, lh-0.3, strings.Join(skillNames(exp.Skills), ", "),
+						"", "L", false,
+					)
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(1.5 * scale)
+			}
+		},
+
+		models.SectionProjects: func() {
+			if len(d.Projects) == 0 {
+				return
+			}
+			compactHeading(pdf, "Projects", scale)
+			for _, pw := range d.Projects {
+				pdf.font("B", 9*scale)
+				titleW := pdf.GetStringWidth(pw.Project.Title) + 2
+				pdf.CellFormat(titleW, lh+0.5, pw.Project.Title, "", 0, "L", false, 0, "")
+				pdf.font("", 8*scale)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.contactLine(projectLinks(pw.Project), "  |  ", lh+0.5, "R")
+				pdf.SetTextColor(0, 0, 0)
+
+				desc := pw.Project.Description
+				if len(pw.Skills) > 0 {
+					desc += " (" + strings.Join(skillNames(pw.Skills), ", ") + ")"
+				}
+				if desc != "" {
+					pdf.font("", 8*scale)
+					pdf.MultiCell(0, lh-0.3, desc, "", "L", false)
+				}
+				pdf.Ln(1.5 * scale)
+			}
+		},
+
+		models.SectionEducation: func() {
+			if len(d.Education) == 0 {
+				return
+			}
+			compactHeading(pdf, "Education", scale)
+			for _, edu := range d.Education {
+				pdf.font("B", 9*scale)
+				degreeW := pdf.GetStringWidth(edu.Degree) + 1
+				pdf.CellFormat(degreeW, lh+0.5, edu.Degree, "", 0, "L", false, 0, "")
+				pdf.font("", 9*scale)
+				collegeW := pdf.GetStringWidth(", "+edu.College) + 2
+				pdf.CellFormat(collegeW, lh+0.5, ", "+edu.College, "", 0, "L", false, 0, "")
+				pdf.font("", 8*scale)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.CellFormat(0, lh+0.5, educationStatus(edu), "", 1, "R", false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+				if details := educationDetails(edu); len(details) > 0 {
+					pdf.font("", 8*scale)
+					pdf.MultiCell(0, lh-0.3, strings.Join(details, "  |  "), "", "L", false)
+				}
+			}
+		},
+
+		models.SectionSkills: func() {
+			if len(d.Skills) == 0 {
+				return
+			}
+			compactHeading(pdf, "Skills", scale)
+			pdf.font("", 8.5*scale)
+			pdf.MultiCell(
+				0, lh, strings.Join(skillNames(d.Skills), ", "),
+				"", "L", false,
+			)
+		},
+
+		models.SectionCertifications: func() {
+			if len(d.Certifications) == 0 {
+				return
+			}
+			compactHeading(pdf, "Certifications", scale)
+			for _, cert := range d.Certifications {
+				compactEntry(pdf, cert.Name, certificationIssuer(cert), certificationDates(cert), "", scale)
+			}
+		},
+
+		models.SectionAwards: func() {
+			if len(d.Awards) == 0 {
+				return
+			}
+			compactHeading(pdf, "Awards", scale)
+			for _, award := range d.Awards {
+				compactEntry(pdf, award.Title, award.Issuer, award.Date, award.Description, scale)
+			}
+		},
+
+		models.SectionPublications: func() {
+			if len(d.Publications) == 0 {
+				return
+			}
+			compactHeading(pdf, "Publications & Talks", scale)
+			for _, pub := range d.Publications {
+				compactEntry(pdf, pub.Title, publicationVenue(pub), pub.Date, pub.Description, scale)
+			}
+		},
+	}
+	for _, cs := range d.Custom {
+		sections[cs.Key()] = func() {
+			paras := cs.Paragraphs()
+			if len(paras) == 0 {
+				return
+			}
+			compactHeading(pdf, cs.Title, scale)
+			pdf.font("", 8.5*scale)
+			pdf.MultiCell(0, lh, strings.Join(paras, " "), "", "L", false)
+		}
+	}
+	for _, name := range d.Sections {
+		if render, ok := sections[name]; ok {
+			render()
+		}
+	}
+
+	return pdf.Fpdf
+}
+
+// compactEntry writes "Title, subtitle" with the date on the right and
+// an optional description below.
+func compactEntry(pdf *resumePDF, title, subtitle, date, desc string, scale float64) {
+	lh := 4 * scale
+	pdf.font("B", 9*scale)
+	titleW := pdf.GetStringWidth(title) + 1
+	pdf.CellFormat(titleW, lh+0.5, title, "", 0, "L", false, 0, "")
+	if subtitle != "" {
+		pdf.font("", 9*scale)
+		subtitle = ", " + subtitle
+		subtitleW := pdf.GetStringWidth(subtitle) + 2
+		pdf.CellFormat(subtitleW, lh+0.5, subtitle, "", 0, "L", false, 0, "")
+	}
+	pdf.font("", 8*scale)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, lh+0.5, date, "", 1, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	if desc != "" {
+		pdf.MultiCell(0, lh-0.3, desc, "", "L", false)
+	}
+}
+
+func compactHeading(pdf *resumePDF, title string, scale float64) {
+	pdf.Ln(2 * scale)
+	pdf.font("B", 10*scale)
+	pdf.SetTextColor(79, 70, 229)
+	pdf.CellFormat(0, 5*scale, strings.ToUpper(title), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	w, _ := pdf.GetPageSize()
+	ml, _, mr, _ := pdf.GetMargins()
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.Line(ml, pdf.GetY(), w-mr, pdf.GetY())
+	pdf.Ln(1.5 * scale)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/DYankee/resume2/fonts"
+	"github.com/DYankee/resume2/models"
+	"github.com/go-pdf/fpdf"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testResume has something in every built-in section, plus a custom one.
+func testResume() *models.Resume {
+	custom := models.CustomSection{
+		ID: 1, Title: "Interests",
+		Body: "Climbing and film photography.\n\nVolunteer tutor.",
+	}
+	return &models.Resume{
+		Name:     "Ada Example",
+		Headline: "Software Engineer",
+		Contact: []models.ContactLink{
+			{Text: "ada@example.com", URL: "mailto:ada@example.com"},
+			{Text: "github.com/ada", URL: "https://github.com/ada"},
+		},
+		About:    []string{"Backend engineer who likes small, dependable tools."},
+		Sections: append(slices.Clone(models.ResumeSections), custom.Key()),
+		Experiences: []models.Experience{
+			{
+				Title: "Senior Engineer", Company: "Acme", Location: "Remote",
+				EmploymentType: "Full-time", StartDate: "2021-03",
+				Description: "Led the billing platform.",
+				Highlights:  []string{"Cut invoice runs from 4h to 20m", "Mentored three engineers"},
+			},
+			{
+				Title: "Engineer", Company: "Initech", StartDate: "2018-06",
+				EndDate: "2021-02", Description: "Built internal APIs in Go.",
+			},
+		},
+		Education: []models.Education{
+			{
+				Degree: "B.S.", College: "State University", Major: "Computer Science",
+				StartDate: "2014-09", EndDate: "2018-05", Gpa: 3.7,
+				Honors: "Cum laude", Coursework: []string{"Databases", "Compilers"},
+			},
+		},
+		Projects: []models.ResumeProject{
+			{
+				Project: models.Project{
+					Title: "Portfolio", Description: "This site, built with Go and HTMX.",
+					RepoURL: "https://github.com/ada/portfolio",
+				},
+				Skills: []models.Skill{{Name: "Go"}, {Name: "HTMX"}},
+			},
+		},
+		Skills: []models.Skill{
+			{Name: "Go", Category: "Languages", Proficiency: 90},
+			{Name: "SQL", Category: "Languages", Proficiency: 80},
+			{Name: "Docker", Category: "Tools", Proficiency: 70},
+		},
+		Certifications: []models.Certification{
+			{Name: "Cloud Practitioner", Issuer: "AWS", IssuedDate: "2022-01", ExpiryDate: "2025-01"},
+		},
+		Awards: []models.Award{
+			{Title: "Hackathon Winner", Issuer: "City Tech Week", Date: "2019-10"},
+		},
+		Publications: []models.Publication{
+			{
+				Title: "Boring Billing", Kind: models.PublicationTalk,
+				Venue: "GopherCon", Date: "2023-07",
+			},
+		},
+		Custom:    []models.CustomSection{custom},
+		Generated: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+}
+
+// pdfString matches the text-showing operators fpdf writes: a literal
+// string, UTF-16BE for the bundled UTF-8 fonts, followed by Tj.
+var pdfString = regexp.MustCompile(`\(((?:[^()\\]|\\.)*)\)\s*Tj`)
+
+// pdfText renders pdf without compression and returns the text of each
+// Tj operator on its own line, in drawing order.
+func pdfText(t *testing.T, pdf *fpdf.Fpdf) string {
+	t.Helper()
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, m := range pdfString.FindAllSubmatch(buf.Bytes(), -1) {
+		raw := unescapePDF(m[1])
+		units := make([]uint16, len(raw)/2)
+		for i := range units {
+			units[i] = uint16(raw[2*i])<<8 | uint16(raw[2*i+1])
+		}
+		lines = append(lines, string(utf16.Decode(units)))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// unescapePDF undoes the backslash escapes of a PDF literal string.
+func unescapePDF(s []byte) []byte {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			out = append(out, s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; c {
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'b':
+			out = append(out, '\b')
+		case 'f':
+			out = append(out, '\f')
+		default:
+			if c >= '0' && c <= '7' {
+				n := 0
+				for j := 0; j < 3 && i < len(s) && s[i] >= '0' && s[i] <= '7'; j++ {
+					n = n*8 + int(s[i]-'0')
+					i++
+				}
+				i--
+				out = append(out, byte(n))
+			} else {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// TestResumeThemesGolden checks the text of each theme's PDF against
+// testdata/<theme>.golden. Run with -update to rewrite them.
+func TestResumeThemesGolden(t *testing.T) {
+	for _, theme := range resumeThemes {
+		t.Run(theme.Name(), func(t *testing.T) {
+			font, ok := fonts.Lookup(theme.DefaultFont())
+			if !ok {
+				t.Fatalf("unknown default font %q", theme.DefaultFont())
+			}
+			got := pdfText(t, theme.Render(testResume(), font))
+
+			golden := filepath.Join("testdata", theme.Name()+".golden")
+			if *update {
+				if err := os.MkdirAll("testdata", 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("PDF text differs from %s:\n--- got\n%s--- want\n%s", golden, got, want)
+			}
+		})
+	}
+}
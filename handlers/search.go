@@ -0,0 +1,50 @@
+// handlers/search.go
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/labstack/echo/v4"
+)
+
+// searchLimit caps the hits returned per content type.
+const searchLimit = 10
+
+type SearchHandler struct {
+	DB *db.DB
+}
+
+func (h *SearchHandler) HandleSearchPage(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	results, err := h.DB.Search(q, searchLimit)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Search failed")
+	}
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.SearchContent(results).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.SearchPage(results).
+		Render(c.Request().Context(), c.Response())
+}
+
+// HandleLiveSearch returns just the grouped results for the search box,
+// and keeps the address bar on a shareable /search?q= URL.
+func (h *SearchHandler) HandleLiveSearch(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	results, err := h.DB.Search(q, searchLimit)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Search failed")
+	}
+
+	c.Response().Header().Set(
+		"HX-Replace-Url", "/search?q="+url.QueryEscape(q),
+	)
+	return pages.SearchResultsList(results).
+		Render(c.Request().Context(), c.Response())
+}
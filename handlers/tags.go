@@ -0,0 +1,65 @@
+// handlers/tags.go
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/DYankee/resume2/db"
+	"github.com/DYankee/resume2/models"
+	"github.com/DYankee/resume2/templates/pages"
+	"github.com/labstack/echo/v4"
+)
+
+type TagsHandler struct {
+	DB *db.DB
+}
+
+// HandleTagPage lists the posts, projects and experience carrying a tag,
+// or 404s when none of them is public. A tag that also names a listed
+// skill, by name or alias, links to the projects using it.
+func (h *TagsHandler) HandleTagPage(c echo.Context) error {
+	name := c.Param("tag")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	tag, err := h.DB.GetTagByName(name)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Tag not found")
+	}
+
+	p := models.TagPage{Tag: *tag}
+	if p.Posts, err = h.DB.GetPostsTagged(tag.ID); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load posts")
+	}
+	projects, err := h.DB.GetProjectsTagged(tag.ID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load projects")
+	}
+	p.Projects = projects
+	if p.Experiences, err = h.DB.GetExperiencesTagged(tag.ID); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load experience")
+	}
+	// Tags only on hidden content stay hidden.
+	if len(p.Posts) == 0 && len(projects) == 0 && len(p.Experiences) == 0 {
+		return c.String(http.StatusNotFound, "Tag not found")
+	}
+	skills, err := h.DB.GetListedSkills()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load skills")
+	}
+	if err := h.DB.LoadSkillAliases(skills); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load skill aliases")
+	}
+	if i := models.FindSkill(skills, tag.Name); i >= 0 {
+		p.Skill = &skills[i]
+	}
+
+	items := projectsWithSkills(h.DB, projects)
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return pages.TagContent(p, items).
+			Render(c.Request().Context(), c.Response())
+	}
+	return pages.TagPage(p, items).
+		Render(c.Request().Context(), c.Response())
+}
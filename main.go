@@ -2,6 +2,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/DYankee/resume2/db"
 	"github.com/DYankee/resume2/handlers"
 	customMw "github.com/DYankee/resume2/middleware"
@@ -28,19 +30,43 @@ func main() {
 
 	aboutH := &handlers.AboutHandler{DB: database}
 	projectsH := &handlers.ProjectsHandler{DB: database}
-	resumeH := &handlers.ResumeHandler{DB: database}
-	adminH := &handlers.AdminHandler{DB: database}
+	resumeCache := handlers.NewResumeCache()
+	resumeH := &handlers.ResumeHandler{DB: database, Cache: resumeCache}
+	postScheduler := handlers.NewPostScheduler(database)
+	go postScheduler.Run(context.Background())
+	adminH := &handlers.AdminHandler{DB: database, Scheduler: postScheduler}
 	authH := &handlers.AuthHandler{DB: database}
+	blogH := &handlers.BlogHandler{DB: database}
+	searchH := &handlers.SearchHandler{DB: database}
+	graphH := &handlers.GraphHandler{DB: database}
+	tagsH := &handlers.TagsHandler{DB: database}
+	previewH := &handlers.PreviewHandler{DB: database}
 
 	// Public pages
 	e.GET("/", aboutH.HandleAboutPage)
 	e.GET("/projects", projectsH.HandleProjectsPage)
+	e.GET("/projects/:slug", projectsH.HandleProjectDetail)
 	e.GET("/resume", resumeH.HandleResumePage)
 	e.GET("/resume/pdf", resumeH.HandleResumePDF)
+	e.GET("/resume.json", resumeH.HandleResumeJSON)
+	e.GET("/resume.md", resumeH.HandleResumeMarkdown)
+	e.GET("/resume.txt", resumeH.HandleResumeText)
+	e.GET("/resume.docx", resumeH.HandleResumeDOCX)
+	e.GET("/resume.tex", resumeH.HandleResumeTeX)
+	e.GET("/resume/:variant", resumeH.HandleVariantPage)
+	e.GET("/resume/:variant/pdf", resumeH.HandleVariantPDF)
+	e.GET("/blog", blogH.HandleBlogPage)
+	e.GET("/blog/:slug", blogH.HandleBlogPost)
+	e.GET("/tags/:tag", tagsH.HandleTagPage)
+	e.GET("/search", searchH.HandleSearchPage)
+	e.GET("/graph", graphH.HandleGraphPage)
+	e.GET("/preview/:token", previewH.HandlePreview)
 
 	// Public HTMX endpoints
 	e.GET("/api/skills", aboutH.HandleFilteredSkills)
 	e.GET("/api/skills/:id", aboutH.HandleSkillDetail)
+	e.GET("/api/search", searchH.HandleLiveSearch)
+	e.GET("/api/graph", graphH.HandleGraphJSON)
 	e.GET(
 		"/api/projects/:id/expand", projectsH.HandleProjectExpand,
 	)
@@ -60,6 +86,7 @@ func main() {
 	// Protected Admin pages
 	admin := e.Group("/admin")
 	admin.Use(customMw.RequireAuth(database))
+	admin.Use(customMw.InvalidateOnWrite(resumeCache.Invalidate))
 
 	// Skill routes
 	admin.GET("", adminH.HandleDashboard)
@@ -71,7 +98,14 @@ func main() {
 	admin.PUT("/skills/:id", adminH.HandleUpdateSkill)
 	admin.DELETE("/skills/:id", adminH.HandleDeleteSkill)
 
+	admin.GET("/categories/table", adminH.HandleAdminCategoriesTable)
 	admin.POST("/categories", adminH.HandleCreateCategory)
+	admin.GET("/categories/:id/edit", adminH.HandleAdminCategoryForm)
+	admin.PUT("/categories/:id", adminH.HandleUpdateCategory)
+	admin.GET("/categories/:id/merge", adminH.HandleAdminCategoryMergeForm)
+	admin.POST("/categories/:id/merge", adminH.HandleMergeCategory)
+	admin.POST("/categories/:id/move", adminH.HandleMoveCategory)
+	admin.DELETE("/categories/:id", adminH.HandleDeleteCategory)
 
 	// Project routes
 	admin.GET("/projects", adminH.HandleAdminProjects)
@@ -82,6 +116,33 @@ func main() {
 	admin.PUT("/projects/:id", adminH.HandleUpdateProject)
 	admin.DELETE("/projects/:id", adminH.HandleDeleteProject)
 
+	admin.GET("/projects/:id/media", adminH.HandleAdminProjectMedia)
+	admin.POST("/projects/:id/media", adminH.HandleCreateProjectMedia)
+	admin.PUT("/projects/:id/media/:mediaID", adminH.HandleUpdateProjectMedia)
+	admin.POST(
+		"/projects/:id/media/:mediaID/move", adminH.HandleMoveProjectMedia,
+	)
+	admin.DELETE(
+		"/projects/:id/media/:mediaID", adminH.HandleDeleteProjectMedia,
+	)
+
+	// Post routes
+	admin.GET("/posts", adminH.HandleAdminPosts)
+	admin.GET("/posts/schedule", adminH.HandleAdminPostSchedule)
+	admin.GET("/posts/:id/schedule", adminH.HandleAdminPostScheduleForm)
+	admin.PUT("/posts/:id/schedule", adminH.HandleSchedulePost)
+
+	// Preview link routes
+	admin.GET("/previews", adminH.HandleAdminPreviews)
+	admin.GET("/previews/table", adminH.HandleAdminPreviewsTable)
+	admin.GET("/previews/new", adminH.HandleAdminPreviewForm)
+	admin.POST("/previews", adminH.HandleCreatePreview)
+	admin.DELETE("/previews/:id", adminH.HandleRevokePreview)
+
+	// Tag routes
+	admin.GET("/tags/suggest", adminH.HandleSuggestTags)
+	admin.GET("/tags/field", adminH.HandleTagField)
+
 	// Experience routes
 	admin.GET("/experience", adminH.HandleAdminExperience)
 	admin.GET("/experience/table", adminH.HandleAdminExperienceTable)
@@ -100,5 +161,75 @@ func main() {
 	admin.PUT("/education/:id", adminH.HandleUpdateEducation)
 	admin.DELETE("/education/:id", adminH.HandleDeleteEducation)
 
+	// Certification routes
+	admin.GET("/certifications", adminH.HandleAdminCertifications)
+	admin.GET("/certifications/new", adminH.HandleAdminCertificationForm)
+	admin.GET("/certifications/:id/edit", adminH.HandleAdminCertificationForm)
+	admin.GET("/certifications/table", adminH.HandleAdminCertificationsTable)
+	admin.POST("/certifications", adminH.HandleCreateCertification)
+	admin.PUT("/certifications/:id", adminH.HandleUpdateCertification)
+	admin.DELETE("/certifications/:id", adminH.HandleDeleteCertification)
+
+	// Award routes
+	admin.GET("/awards", adminH.HandleAdminAwards)
+	admin.GET("/awards/new", adminH.HandleAdminAwardForm)
+	admin.GET("/awards/:id/edit", adminH.HandleAdminAwardForm)
+	admin.GET("/awards/table", adminH.HandleAdminAwardsTable)
+	admin.POST("/awards", adminH.HandleCreateAward)
+	admin.PUT("/awards/:id", adminH.HandleUpdateAward)
+	admin.DELETE("/awards/:id", adminH.HandleDeleteAward)
+
+	// Publication routes
+	admin.GET("/publications", adminH.HandleAdminPublications)
+	admin.GET("/publications/new", adminH.HandleAdminPublicationForm)
+	admin.GET("/publications/:id/edit", adminH.HandleAdminPublicationForm)
+	admin.GET("/publications/table", adminH.HandleAdminPublicationsTable)
+	admin.POST("/publications", adminH.HandleCreatePublication)
+	admin.PUT("/publications/:id", adminH.HandleUpdatePublication)
+	admin.DELETE("/publications/:id", adminH.HandleDeletePublication)
+
+	// Resume routes
+	admin.GET("/resume", adminH.HandleAdminResume)
+	admin.POST("/resume/projects", adminH.HandleAddResumeProject)
+	admin.POST(
+		"/resume/projects/:id/move", adminH.HandleMoveResumeProject,
+	)
+	admin.DELETE("/resume/projects/:id", adminH.HandleRemoveResumeProject)
+	admin.POST("/resume/import/preview", adminH.HandleImportPreview)
+	admin.POST("/resume/import", adminH.HandleImport)
+
+	// Section routes
+	admin.GET("/sections", adminH.HandleAdminSections)
+	admin.GET("/sections/layouts", adminH.HandleAdminSectionLayouts)
+	admin.PUT("/sections/layouts/:surface", adminH.HandleUpdateSectionLayout)
+	admin.GET("/sections/custom/new", adminH.HandleAdminCustomSectionForm)
+	admin.GET("/sections/custom/:id/edit", adminH.HandleAdminCustomSectionForm)
+	admin.GET("/sections/custom/table", adminH.HandleAdminCustomSectionsTable)
+	admin.POST("/sections/custom", adminH.HandleCreateCustomSection)
+	admin.PUT("/sections/custom/:id", adminH.HandleUpdateCustomSection)
+	admin.DELETE("/sections/custom/:id", adminH.HandleDeleteCustomSection)
+
+	// Resume variant routes
+	admin.GET("/variants", adminH.HandleAdminVariants)
+	admin.GET("/variants/new", adminH.HandleAdminVariantForm)
+	admin.GET("/variants/:id/edit", adminH.HandleAdminVariantForm)
+	admin.GET("/variants/table", adminH.HandleAdminVariantsTable)
+	admin.POST("/variants", adminH.HandleCreateVariant)
+	admin.PUT("/variants/:id", adminH.HandleUpdateVariant)
+	admin.DELETE("/variants/:id", adminH.HandleDeleteVariant)
+
+	// Job match routes
+	admin.GET("/match", adminH.HandleAdminJobMatch)
+	admin.POST("/match", adminH.HandleJobMatch)
+	admin.POST("/match/variant", adminH.HandleJobMatchVariant)
+
+	// Profile routes
+	admin.GET("/profile", adminH.HandleAdminProfile)
+	admin.PUT("/profile", adminH.HandleUpdateProfile)
+
+	// Settings routes
+	admin.GET("/settings", adminH.HandleAdminSettings)
+	admin.PUT("/settings", adminH.HandleUpdateSettings)
+
 	e.Logger.Fatal(e.Start(":8080"))
 }
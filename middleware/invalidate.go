@@ -0,0 +1,24 @@
+// middleware/invalidate.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InvalidateOnWrite calls invalidate after every request that may have
+// changed data, i.e. anything but GET and HEAD. Failed requests count
+// too, since a handler can fail partway through.
+func InvalidateOnWrite(invalidate func()) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			method := c.Request().Method
+			if method != http.MethodGet && method != http.MethodHead {
+				invalidate()
+			}
+			return err
+		}
+	}
+}
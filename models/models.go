@@ -1,40 +1,274 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Visibility values shared by projects and skills.
+const (
+	VisibilityPublic   = "public"   // listed on the public site
+	VisibilityUnlisted = "unlisted" // reachable by direct link only
+	VisibilityPrivate  = "private"  // admin only
+	VisibilityFeatured = "featured" // listed and pinned to the top
+)
+
+// Visibilities lists every visibility value in the order the admin
+// forms offer them.
+var Visibilities = []string{
+	VisibilityPublic,
+	VisibilityFeatured,
+	VisibilityUnlisted,
+	VisibilityPrivate,
+}
+
+// ValidVisibility reports whether v is a known visibility value.
+func ValidVisibility(v string) bool {
+	for _, known := range Visibilities {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
 
 type Skill struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category"`
-	Description string    `json:"description"`
-	IconURL     string    `json:"icon_url"`
-	Proficiency int8      `json:"proficiency"`
-	Deleted     bool      `json:"deleted"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	DeletedAt   time.Time `json:"deleted_at"`
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Category    string       `json:"category"`
+	Description string       `json:"description"`
+	IconURL     string       `json:"icon_url"`
+	Proficiency int8         `json:"proficiency"`
+	Visibility  string       `json:"visibility"`
+	Aliases     []string     `json:"aliases"` // filled by db.LoadSkillAliases
+	Metrics     SkillMetrics `json:"metrics"` // filled by db.LoadSkillMetrics
+	Deleted     bool         `json:"deleted"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   time.Time    `json:"deleted_at"`
+}
+
+// SkillMetrics describe how much a skill has actually been used,
+// computed from its linked projects and work history rather than the
+// manual proficiency.
+type SkillMetrics struct {
+	Projects int    `json:"projects"`  // linked projects that aren't private
+	Months   int    `json:"months"`    // covered by linked experience, overlaps merged
+	LastUsed string `json:"last_used"` // YYYY-MM of latest linked experience, or empty
+	Current  bool   `json:"current"`   // linked to an ongoing experience
+}
+
+// Duration formats Months as e.g. "8 mos", "1 yr" or "2.5 yrs", rounded
+// to the half year. It is empty when no experience is linked.
+func (m SkillMetrics) Duration() string {
+	switch {
+	case m.Months == 0:
+		return ""
+	case m.Months == 1:
+		return "1 mo"
+	case m.Months < 12:
+		return fmt.Sprintf("%d mos", m.Months)
+	}
+	years := float64((m.Months+3)/6) / 2
+	if years == 1 {
+		return "1 yr"
+	}
+	return fmt.Sprintf("%g yrs", years)
+}
+
+// ProjectsLabel formats Projects, e.g. "1 project" or "3 projects".
+func (m SkillMetrics) ProjectsLabel() string {
+	if m.Projects == 1 {
+		return "1 project"
+	}
+	return fmt.Sprintf("%d projects", m.Projects)
+}
+
+// LastUsedLabel is "Present" for a skill in current use, else LastUsed.
+func (m SkillMetrics) LastUsedLabel() string {
+	if m.Current {
+		return "Present"
+	}
+	return m.LastUsed
+}
+
+// Skill orders for the resume.
+const (
+	SkillOrderManual = "manual" // featured first, then by proficiency
+	SkillOrderRecent = "recent" // most recently used first
+	SkillOrderDepth  = "depth"  // longest used first
+)
+
+// SkillOrders lists every skill order in the order the settings page
+// offers them.
+var SkillOrders = []string{SkillOrderManual, SkillOrderRecent, SkillOrderDepth}
+
+func SkillOrderLabel(order string) string {
+	switch order {
+	case SkillOrderRecent:
+		return "Most recently used"
+	case SkillOrderDepth:
+		return "Most experience"
+	}
+	return "Manual (proficiency)"
+}
+
+// SortSkills reorders skills by their metrics. Ties, and the manual
+// order, keep the existing order.
+func SortSkills(skills []Skill, order string) {
+	depth := func(a, b Skill) int {
+		if c := b.Metrics.Months - a.Metrics.Months; c != 0 {
+			return c
+		}
+		return b.Metrics.Projects - a.Metrics.Projects
+	}
+	switch order {
+	case SkillOrderDepth:
+		slices.SortStableFunc(skills, depth)
+	case SkillOrderRecent:
+		slices.SortStableFunc(skills, func(a, b Skill) int {
+			if a.Metrics.Current != b.Metrics.Current {
+				if a.Metrics.Current {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(b.Metrics.LastUsed, a.Metrics.LastUsed); c != 0 {
+				return c
+			}
+			return depth(a, b)
+		})
+	}
+}
+
+// Listed reports whether the skill shows up in public listings.
+func (s Skill) Listed() bool {
+	return s.Visibility == VisibilityPublic || s.Visibility == VisibilityFeatured
+}
+
+// NormalizeSkillName folds a skill name or alias to the form names are
+// compared in: lower case, without spaces, hyphens, underscores or dots.
+// A leading dot reads as "dot", so ".NET" is "dotnet".
+func NormalizeSkillName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasPrefix(name, ".") {
+		name = "dot" + name[1:]
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.':
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// Answers reports whether name is the skill's name or one of its
+// aliases, once normalized.
+func (s Skill) Answers(name string) bool {
+	key := NormalizeSkillName(name)
+	if key == "" {
+		return false
+	}
+	if NormalizeSkillName(s.Name) == key {
+		return true
+	}
+	return slices.ContainsFunc(s.Aliases, func(a string) bool {
+		return NormalizeSkillName(a) == key
+	})
+}
+
+// FindSkill returns the index of the skill name refers to, or -1. A skill
+// named name wins over one that only has it as an alias.
+func FindSkill(skills []Skill, name string) int {
+	key := NormalizeSkillName(name)
+	if key == "" {
+		return -1
+	}
+	if i := slices.IndexFunc(skills, func(s Skill) bool {
+		return NormalizeSkillName(s.Name) == key
+	}); i >= 0 {
+		return i
+	}
+	return slices.IndexFunc(skills, func(s Skill) bool { return s.Answers(name) })
 }
 
 type Skill_category struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	SortOrder int    `json:"sort_order"`
+	Skills    int    `json:"skills"` // non-deleted skills filed under it
 }
 
 type Project struct {
 	ID           int64     `json:"id"`
 	DisplayOrder int32     `json:"display_order"`
+	Slug         string    `json:"slug"`
 	Title        string    `json:"title"`
 	Description  string    `json:"description"`
 	LongDesc     string    `json:"long_desc"`
 	ImageURL     string    `json:"image_url"`
 	RepoURL      string    `json:"repo_url"`
 	LiveURL      string    `json:"live_url"`
+	Visibility   string    `json:"visibility"`
+	Tags         []string  `json:"tags"` // filled by GetProjectByID and GetProjectBySlug
 	Deleted      bool      `json:"deleted"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 	DeletedAt    time.Time `json:"deleted_at"`
 }
 
+// Listed reports whether the project shows up in public listings.
+func (p Project) Listed() bool {
+	return p.Visibility == VisibilityPublic || p.Visibility == VisibilityFeatured
+}
+
+// Media kinds for ProjectMedia.
+const (
+	MediaImage = "image" // still image or GIF
+	MediaVideo = "video" // video file played with <video>
+	MediaEmbed = "embed" // hosted video such as YouTube or Vimeo
+)
+
+// MediaKinds lists every media kind in the order the admin offers them.
+var MediaKinds = []string{MediaImage, MediaVideo, MediaEmbed}
+
+type ProjectMedia struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Kind      string `json:"kind"`
+	URL       string `json:"url"`
+	Caption   string `json:"caption"`
+	AltText   string `json:"alt_text"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// ProjectFilter narrows the public projects grid. Zero values match
+// everything; a project must use every skill in SkillIDs.
+type ProjectFilter struct {
+	SkillIDs   []int64
+	CategoryID int64
+	Query      string
+}
+
+// Empty reports whether the filter matches every project.
+func (f ProjectFilter) Empty() bool {
+	return len(f.SkillIDs) == 0 && f.CategoryID == 0 && f.Query == ""
+}
+
+// HasSkill reports whether id is one of the selected skills.
+func (f ProjectFilter) HasSkill(id int64) bool {
+	for _, s := range f.SkillIDs {
+		if s == id {
+			return true
+		}
+	}
+	return false
+}
+
 type SkillUse struct {
 	ID         int64 `json:"id"`
 	Skill_ID   int64 `json:"skill_id"`
@@ -42,11 +276,178 @@ type SkillUse struct {
 }
 
 type Experience struct {
+	ID             int64     `json:"id"`
+	Title          string    `json:"title"`
+	Company        string    `json:"company"`
+	Location       string    `json:"location"`
+	EmploymentType string    `json:"employment_type"` // one of EmploymentTypes, or empty
+	StartDate      string    `json:"start_date"`      // YYYY-MM
+	EndDate        string    `json:"end_date"`        // YYYY-MM; empty = "Present"
+	Description    string    `json:"description"`
+	Highlights     []string  `json:"highlights"` // bullet points, in order
+	Skills         []Skill   `json:"skills"`
+	Tags           []string  `json:"tags"`
+	Deleted        bool      `json:"deleted"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	DeletedAt      time.Time `json:"deleted_at"`
+}
+
+// Subtitle joins the location and employment type, e.g.
+// "Utica, NY · Part-time". It is empty when neither is set.
+func (e Experience) Subtitle() string {
+	var parts []string
+	if e.Location != "" {
+		parts = append(parts, e.Location)
+	}
+	if e.EmploymentType != "" {
+		parts = append(parts, EmploymentLabel(e.EmploymentType))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Employment types for Experience.EmploymentType.
+const (
+	EmploymentFullTime   = "full-time"
+	EmploymentPartTime   = "part-time"
+	EmploymentContract   = "contract"
+	EmploymentFreelance  = "freelance"
+	EmploymentInternship = "internship"
+)
+
+// EmploymentTypes lists every employment type in the order the admin
+// form offers them.
+var EmploymentTypes = []string{
+	EmploymentFullTime,
+	EmploymentPartTime,
+	EmploymentContract,
+	EmploymentFreelance,
+	EmploymentInternship,
+}
+
+// ValidEmploymentType reports whether t is a known employment type or
+// empty.
+func ValidEmploymentType(t string) bool {
+	return t == "" || slices.Contains(EmploymentTypes, t)
+}
+
+func EmploymentLabel(t string) string {
+	switch t {
+	case EmploymentFullTime:
+		return "Full-time"
+	case EmploymentPartTime:
+		return "Part-time"
+	case EmploymentContract:
+		return "Contract"
+	case EmploymentFreelance:
+		return "Freelance"
+	case EmploymentInternship:
+		return "Internship"
+	}
+	return t
+}
+
+// ParseYearMonth checks that s is a year and month and returns it as
+// "YYYY-MM". A full "YYYY-MM-DD" date is accepted and the day dropped.
+func ParseYearMonth(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == len("2006-01-02") {
+		s = s[:len("2006-01")]
+	}
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a year and month (YYYY-MM)", s)
+	}
+	if t.Year() < 1900 || t.Year() > 2100 {
+		return "", fmt.Errorf("%q is out of range", s)
+	}
+	return t.Format("2006-01"), nil
+}
+
+type Education struct {
+	ID          int64     `json:"id"`
+	Degree      string    `json:"degree"`
+	College     string    `json:"college"`
+	Major       string    `json:"major"`
+	Minor       string    `json:"minor"`
+	StartDate   string    `json:"start_date"` // YYYY-MM, or empty
+	EndDate     string    `json:"end_date"`   // YYYY-MM, or empty; expected while in progress
+	Gpa         float64   `json:"gpa"`
+	In_progress bool      `json:"in_progress"`
+	Honors      string    `json:"honors"`
+	Coursework  []string  `json:"coursework"` // relevant courses, in order
+	Projects    []Project `json:"projects"`   // linked class projects
+	Deleted     bool      `json:"deleted"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// FieldOfStudy describes the major and minor, e.g. "Computer Science,
+// minor in Mathematics". It is empty when neither is set.
+func (e Education) FieldOfStudy() string {
+	switch {
+	case e.Minor == "":
+		return e.Major
+	case e.Major == "":
+		return "Minor in " + e.Minor
+	}
+	return e.Major + ", minor in " + e.Minor
+}
+
+// Dates formats the attendance range, e.g. "2021-09 - 2025-05". An
+// in-progress end date reads "Expected 2025-05". It is empty when no
+// dates are set.
+func (e Education) Dates() string {
+	end := e.EndDate
+	if e.In_progress {
+		if end == "" {
+			end = "Present"
+		} else {
+			end = "Expected " + end
+		}
+	}
+	switch {
+	case e.StartDate == "":
+		return end
+	case end == "":
+		return e.StartDate
+	}
+	return e.StartDate + "  -  " + end
+}
+
+// Certification is a professional certification or license.
+type Certification struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Issuer        string    `json:"issuer"`
+	IssuedDate    string    `json:"issued_date"` // YYYY-MM
+	ExpiryDate    string    `json:"expiry_date"` // YYYY-MM; empty = never expires
+	CredentialID  string    `json:"credential_id"`
+	CredentialURL string    `json:"credential_url"`
+	Deleted       bool      `json:"deleted"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	DeletedAt     time.Time `json:"deleted_at"`
+}
+
+// Expired reports whether the certification's expiry month is over.
+func (c Certification) Expired(now time.Time) bool {
+	return c.ExpiryDate != "" && c.ExpiryDate < now.Format("2006-01")
+}
+
+// ExpiresWithin reports whether the certification is still valid but
+// expires within the given number of months.
+func (c Certification) ExpiresWithin(now time.Time, months int) bool {
+	return c.ExpiryDate != "" && !c.Expired(now) &&
+		c.ExpiryDate <= now.AddDate(0, months, 0).Format("2006-01")
+}
+
+// Award is an award, honor or prize.
+type Award struct {
 	ID          int64     `json:"id"`
 	Title       string    `json:"title"`
-	Company     string    `json:"company"`
-	StartDate   string    `json:"start_date"`
-	EndDate     string    `json:"end_date"` // empty = "Present"
+	Issuer      string    `json:"issuer"`
+	Date        string    `json:"date"` // YYYY-MM
 	Description string    `json:"description"`
 	Deleted     bool      `json:"deleted"`
 	CreatedAt   time.Time `json:"created_at"`
@@ -54,24 +455,517 @@ type Experience struct {
 	DeletedAt   time.Time `json:"deleted_at"`
 }
 
-type Education struct {
-	ID          int64   `json:"id"`
-	Degree      string  `json:"degree"`
-	College     string  `json:"college"`
-	Gpa         float64 `json:"gpa"`
-	In_progress bool    `json:"in_progress"`
+// Publication is a published article or paper, or a talk given at an
+// event.
+type Publication struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Kind        string    `json:"kind"`  // PublicationArticle or PublicationTalk
+	Venue       string    `json:"venue"` // publisher, journal or event
+	Date        string    `json:"date"`  // YYYY-MM
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Deleted     bool      `json:"deleted"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// Publication kinds for Publication.Kind.
+const (
+	PublicationArticle = "publication"
+	PublicationTalk    = "talk"
+)
+
+// PublicationKinds lists every publication kind in the order the admin
+// form offers them.
+var PublicationKinds = []string{PublicationArticle, PublicationTalk}
+
+// ValidPublicationKind reports whether k is a known publication kind.
+func ValidPublicationKind(k string) bool {
+	return slices.Contains(PublicationKinds, k)
+}
+
+// PublicationLabel is the display name of a publication kind.
+func PublicationLabel(k string) string {
+	if k == PublicationTalk {
+		return "Talk"
+	}
+	return "Publication"
 }
 
 type BlogPost struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Excerpt     string    `json:"excerpt"`
+	Content     string    `json:"content"`
+	Tags        []string  `json:"tags"`
+	Published   bool      `json:"published"`
+	PublishAt   time.Time `json:"publish_at"`   // zero = as soon as published
+	UnpublishAt time.Time `json:"unpublish_at"` // zero = never expires
+	Deleted     bool      `json:"deleted"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// Post states, derived from Published and the schedule.
+const (
+	PostDraft     = "draft"
+	PostScheduled = "scheduled" // published, waiting for PublishAt
+	PostLive      = "live"
+	PostExpired   = "expired" // UnpublishAt has passed
+)
+
+// Status is the post's state at now.
+func (p BlogPost) Status(now time.Time) string {
+	switch {
+	case !p.UnpublishAt.IsZero() && !p.UnpublishAt.After(now):
+		return PostExpired
+	case !p.Published:
+		return PostDraft
+	case p.PublishAt.After(now):
+		return PostScheduled
+	}
+	return PostLive
+}
+
+// Date is the date a post is shown with: when it was scheduled to go
+// live, or else when it was written.
+func (p BlogPost) Date() time.Time {
+	if !p.PublishAt.IsZero() {
+		return p.PublishAt
+	}
+	return p.CreatedAt
+}
+
+// PostEvent is a post going live or expiring, shown on the publishing
+// calendar.
+type PostEvent struct {
+	Post    BlogPost
+	At      time.Time
+	Expires bool
+}
+
+// CalendarDay is one cell of the publishing calendar.
+type CalendarDay struct {
+	Date    time.Time
+	InMonth bool // false for the days padding out the first and last week
+	Events  []PostEvent
+}
+
+// PostCalendar lays out a month of post events in weeks starting on
+// Sunday.
+type PostCalendar struct {
+	Month time.Time // first day of the month
+	Weeks [][7]CalendarDay
+}
+
+// Prev and Next are the neighbouring months as YYYY-MM.
+func (c PostCalendar) Prev() string { return c.Month.AddDate(0, -1, 0).Format("2006-01") }
+func (c PostCalendar) Next() string { return c.Month.AddDate(0, 1, 0).Format("2006-01") }
+
+// NewPostCalendar places the publish and expiry times of posts on the
+// calendar for month, in month's location.
+func NewPostCalendar(month time.Time, posts []BlogPost) PostCalendar {
+	loc := month.Location()
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	cal := PostCalendar{Month: first}
+
+	start := first.AddDate(0, 0, -int(first.Weekday()))
+	for day := start; day.Before(first.AddDate(0, 1, 0)); {
+		var week [7]CalendarDay
+		for i := range week {
+			week[i] = CalendarDay{Date: day, InMonth: day.Month() == first.Month()}
+			day = day.AddDate(0, 0, 1)
+		}
+		cal.Weeks = append(cal.Weeks, week)
+	}
+
+	place := func(e PostEvent) {
+		at := e.At.In(loc)
+		days := int(time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc).Sub(start).Hours()+12) / 24
+		if days >= 0 && days < 7*len(cal.Weeks) {
+			d := &cal.Weeks[days/7][days%7]
+			d.Events = append(d.Events, e)
+		}
+	}
+	for _, p := range posts {
+		if !p.PublishAt.IsZero() {
+			place(PostEvent{Post: p, At: p.PublishAt})
+		}
+		if !p.UnpublishAt.IsZero() {
+			place(PostEvent{Post: p, At: p.UnpublishAt, Expires: true})
+		}
+	}
+	for w := range cal.Weeks {
+		for _, d := range cal.Weeks[w] {
+			slices.SortFunc(d.Events, func(a, b PostEvent) int { return a.At.Compare(b.At) })
+		}
+	}
+	return cal
+}
+
+// Preview link kinds: what a PreviewLink shows.
+const (
+	PreviewPost    = "post"
+	PreviewProject = "project"
+)
+
+// PreviewLink lets anyone holding its signed URL see a post or project
+// that isn't public yet, until it expires or is revoked.
+type PreviewLink struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	ItemID    int64     `json:"item_id"`
+	Title     string    `json:"title"` // of the post or project
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Profile is the site owner's contact details and summary, shown in the
+// resume header. There is a single profile row.
+type Profile struct {
+	Name        string    `json:"name"`
+	Headline    string    `json:"headline"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Location    string    `json:"location"`
+	LinkedInURL string    `json:"linkedin_url"`
+	GitHubURL   string    `json:"github_url"`
+	WebsiteURL  string    `json:"website_url"`
+	Summary     string    `json:"summary"` // paragraphs separated by blank lines
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Paragraphs splits Summary into paragraphs.
+func (p Profile) Paragraphs() []string {
+	return Paragraphs(p.Summary)
+}
+
+// Paragraphs splits s on blank lines, dropping empty paragraphs.
+func Paragraphs(s string) []string {
+	var paras []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			paras = append(paras, para)
+		}
+	}
+	return paras
+}
+
+// ContactLink is one contact detail; URL is empty for plain text such as
+// a location.
+type ContactLink struct {
+	Text string
+	URL  string
+}
+
+// ContactLinks lists the profile's filled-in contact details in display
+// order, with web addresses shortened to host and path.
+func (p Profile) ContactLinks() []ContactLink {
+	var links []ContactLink
+	if p.Email != "" {
+		links = append(links, ContactLink{p.Email, "mailto:" + p.Email})
+	}
+	if p.Phone != "" {
+		links = append(links, ContactLink{p.Phone, "tel:" + p.Phone})
+	}
+	if p.Location != "" {
+		links = append(links, ContactLink{Text: p.Location})
+	}
+	for _, u := range []string{p.LinkedInURL, p.GitHubURL, p.WebsiteURL} {
+		if u != "" {
+			links = append(links, ContactLink{ShortURL(u), u})
+		}
+	}
+	return links
+}
+
+// ShortURL drops the scheme, a leading "www." and a trailing slash, for
+// printing a link as text.
+func ShortURL(u string) string {
+	u = strings.TrimPrefix(u, "https://")
+	u = strings.TrimPrefix(u, "http://")
+	u = strings.TrimPrefix(u, "www.")
+	return strings.TrimSuffix(u, "/")
+}
+
+// Resume sections, in their default order.
+const (
+	SectionAbout          = "about"
+	SectionEducation      = "education"
+	SectionExperience     = "experience"
+	SectionProjects       = "projects"
+	SectionSkills         = "skills"
+	SectionCertifications = "certifications"
+	SectionAwards         = "awards"
+	SectionPublications   = "publications"
+)
+
+var ResumeSections = []string{
+	SectionAbout, SectionEducation, SectionExperience,
+	SectionProjects, SectionSkills, SectionCertifications,
+	SectionAwards, SectionPublications,
+}
+
+// Surfaces whose sections the admin can reorder and hide.
+const (
+	SurfaceAbout  = "about"  // the home page
+	SurfaceResume = "resume" // the web resume
+	SurfacePDF    = "pdf"    // the PDF and the other downloads
+)
+
+var Surfaces = []string{SurfaceAbout, SurfaceResume, SurfacePDF}
+
+// DefaultSections is a surface's order until the admin sets one. On the
+// about page, About is the introduction and Projects the featured ones.
+func DefaultSections(surface string) []string {
+	if surface == SurfaceAbout {
+		return []string{
+			SectionAbout, SectionProjects, SectionSkills, SectionEducation,
+			SectionExperience, SectionCertifications, SectionAwards,
+			SectionPublications,
+		}
+	}
+	return ResumeSections
+}
+
+// CustomSection is a free-text section such as "Interests", placed on
+// each surface like the built-in ones.
+type CustomSection struct {
 	ID        int64     `json:"id"`
 	Title     string    `json:"title"`
-	Slug      string    `json:"slug"`
-	Excerpt   string    `json:"excerpt"`
-	Content   string    `json:"content"`
-	Tags      string    `json:"tags"`
-	Published bool      `json:"published"`
+	Body      string    `json:"body"` // paragraphs separated by blank lines
 	Deleted   bool      `json:"deleted"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt time.Time `json:"deleted_at"`
 }
+
+// Key names the section in a surface's order, e.g. "custom-3".
+func (s CustomSection) Key() string {
+	return fmt.Sprintf("custom-%d", s.ID)
+}
+
+func (s CustomSection) Paragraphs() []string {
+	return Paragraphs(s.Body)
+}
+
+// FindCustomSection returns the section placed under key.
+func FindCustomSection(sections []CustomSection, key string) (CustomSection, bool) {
+	for _, s := range sections {
+		if s.Key() == key {
+			return s, true
+		}
+	}
+	return CustomSection{}, false
+}
+
+// Resume is the content of one resume, ready to render. The web page
+// and every PDF theme are built from it.
+type Resume struct {
+	Name        string
+	Headline    string
+	Contact     []ContactLink
+	About       []string
+	Sections    []string // shown in this order; others are hidden
+	Experiences []Experience
+	Education   []Education
+	Projects    []ResumeProject
+	Skills      []Skill
+
+	Certifications []Certification
+	Awards         []Award
+	Publications   []Publication
+	Custom         []CustomSection // placed by Key in Sections
+
+	Generated time.Time
+}
+
+type ResumeProject struct {
+	Project Project
+	Skills  []Skill
+}
+
+// ResumeVariant is a named resume aimed at one kind of role, served at
+// /resume/<Slug>. An empty ID list means that section keeps the default
+// resume's items; Headline and Summary override the profile when set.
+type ResumeVariant struct {
+	ID            int64     `json:"id"`
+	Slug          string    `json:"slug"`
+	Name          string    `json:"name"`
+	Headline      string    `json:"headline"`
+	Summary       string    `json:"summary"`
+	Sections      []string  `json:"sections"`
+	ExperienceIDs []int64   `json:"experience_ids"`
+	SkillIDs      []int64   `json:"skill_ids"`
+	ProjectIDs    []int64   `json:"project_ids"`
+	EducationIDs  []int64   `json:"education_ids"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Search result kinds, one per indexed table.
+const (
+	SearchProject    = "project"
+	SearchPost       = "post"
+	SearchSkill      = "skill"
+	SearchExperience = "experience"
+)
+
+// SnippetPart is a run of snippet text; Match marks the query terms.
+type SnippetPart struct {
+	Text  string
+	Match bool
+}
+
+type SearchHit struct {
+	Kind    string
+	Title   string
+	URL     string
+	Snippet []SnippetPart
+	Rank    float64 // bm25, lower is better
+}
+
+// SearchResults groups hits by content type, each ranked best first.
+type SearchResults struct {
+	Query       string
+	Projects    []SearchHit
+	Posts       []SearchHit
+	Skills      []SearchHit
+	Experiences []SearchHit
+}
+
+func (r SearchResults) Total() int {
+	return len(r.Projects) + len(r.Posts) + len(r.Skills) + len(r.Experiences)
+}
+
+// Graph node kinds.
+const (
+	GraphSkill    = "skill"
+	GraphProject  = "project"
+	GraphCategory = "category"
+)
+
+// Graph is the skill relationship graph served at /api/graph: skills,
+// the projects that use them and the categories they belong to.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID     string `json:"id"`   // kind and database ID, e.g. "skill-3"
+	Kind   string `json:"kind"` // one of the Graph* kinds
+	Label  string `json:"label"`
+	Weight int    `json:"weight"`        // number of edges, for sizing
+	URL    string `json:"url,omitempty"` // HTMX partial to show on click
+}
+
+// GraphEdge links two nodes by ID. Skill–project and skill–category
+// edges weigh 1; skill–skill edges weigh the number of projects the two
+// skills share.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Weight int    `json:"weight"`
+}
+
+// JobMatch compares a job posting with the skill inventory.
+type JobMatch struct {
+	Title       string // job title or company, for naming a variant
+	Matched     []SkillMatch
+	Missing     []string // keywords in the posting with no matching skill
+	Experiences []int64  // experiences that use a matched skill
+}
+
+// SkillMatch is a skill the posting asks for, with the projects that
+// back it up.
+type SkillMatch struct {
+	Skill    Skill
+	Term     string // as written in the posting
+	Projects []Project
+}
+
+// Coverage is the percentage of the posting's recognised keywords that
+// match a skill.
+func (m JobMatch) Coverage() int {
+	total := len(m.Matched) + len(m.Missing)
+	if total == 0 {
+		return 0
+	}
+	return len(m.Matched) * 100 / total
+}
+
+// SkillIDs lists the matched skills.
+func (m JobMatch) SkillIDs() []int64 {
+	ids := make([]int64, len(m.Matched))
+	for i, sm := range m.Matched {
+		ids[i] = sm.Skill.ID
+	}
+	return ids
+}
+
+// ProjectIDs lists the projects backing the matched skills, each once.
+func (m JobMatch) ProjectIDs() []int64 {
+	var ids []int64
+	for _, sm := range m.Matched {
+		for _, p := range sm.Projects {
+			if !slices.Contains(ids, p.ID) {
+				ids = append(ids, p.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// Kinds of content that can carry tags.
+const (
+	TagKindPost       = "post"
+	TagKindProject    = "project"
+	TagKindExperience = "experience"
+)
+
+// Tag is a label shared by blog posts, projects and experiences. Tag
+// names are compared case-insensitively.
+type Tag struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"` // taggings, of any kind
+}
+
+// TagURL is the page listing everything tagged name.
+func TagURL(name string) string {
+	return "/tags/" + url.PathEscape(name)
+}
+
+// SplitTags parses a comma-separated tag list, trimming each tag,
+// collapsing inner whitespace and dropping empty and repeated tags.
+func SplitTags(s string) []string {
+	var tags []string
+	for _, t := range strings.Split(s, ",") {
+		t = strings.Join(strings.Fields(t), " ")
+		if t != "" && !slices.ContainsFunc(tags, func(seen string) bool {
+			return strings.EqualFold(seen, t)
+		}) {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// TagPage is everything on the public site carrying one tag.
+type TagPage struct {
+	Tag         Tag
+	Skill       *Skill // listed skill the tag names, by name or alias
+	Posts       []BlogPost
+	Projects    []Project
+	Experiences []Experience
+}
+
+// Empty reports whether nothing public carries the tag.
+func (p TagPage) Empty() bool {
+	return len(p.Posts) == 0 && len(p.Projects) == 0 && len(p.Experiences) == 0
+}
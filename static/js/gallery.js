@@ -0,0 +1,74 @@
+// static/js/gallery.js
+// Carousel and lightbox for project media galleries (see
+// templates/pages/gallery.templ). Listeners are delegated from document in
+// the capture phase so galleries swapped in by HTMX work without
+// re-binding, even inside cards that stop click propagation.
+
+(function () {
+	function step(gallery, delta) {
+		const slides = gallery.querySelectorAll("[data-slide]");
+		if (slides.length === 0) return;
+
+		let current = 0;
+		slides.forEach((s, i) => {
+			if (!s.classList.contains("hidden")) current = i;
+		});
+		const next = (current + delta + slides.length) % slides.length;
+
+		slides[current].classList.add("hidden");
+		slides[next].classList.remove("hidden");
+		slides[current].querySelectorAll("video").forEach((v) => v.pause());
+
+		const count = gallery.querySelector("[data-gallery-count]");
+		if (count) count.textContent = `${next + 1} / ${slides.length}`;
+	}
+
+	function closeLightbox() {
+		document.getElementById("lightbox")?.remove();
+	}
+
+	function openLightbox(img) {
+		closeLightbox();
+
+		const overlay = document.createElement("div");
+		overlay.id = "lightbox";
+		overlay.className =
+			"fixed inset-0 bg-black/60 flex flex-col items-center justify-center z-50 p-8 cursor-pointer";
+
+		const full = document.createElement("img");
+		full.src = img.src;
+		full.alt = img.alt;
+		full.className = "max-h-full max-w-full rounded-lg";
+		overlay.appendChild(full);
+
+		const caption = img.dataset.caption;
+		if (caption) {
+			const p = document.createElement("p");
+			p.textContent = caption;
+			p.className = "mt-4 text-sm text-gray-300 text-center";
+			overlay.appendChild(p);
+		}
+
+		overlay.addEventListener("click", closeLightbox);
+		document.body.appendChild(overlay);
+	}
+
+	document.addEventListener(
+		"click",
+		(e) => {
+			const nav = e.target.closest("[data-gallery-prev], [data-gallery-next]");
+			if (nav) {
+				const delta = nav.hasAttribute("data-gallery-next") ? 1 : -1;
+				step(nav.closest("[data-gallery]"), delta);
+				return;
+			}
+			const img = e.target.closest("img[data-lightbox]");
+			if (img) openLightbox(img);
+		},
+		true,
+	);
+
+	document.addEventListener("keydown", (e) => {
+		if (e.key === "Escape") closeLightbox();
+	});
+})();
@@ -0,0 +1,268 @@
+// static/js/graph.js
+// Force-directed skill graph (see templates/pages/graph.templ). Any
+// element with data-skill-graph="<url>" is filled with an SVG drawn from
+// the JSON at that URL. Clicking a node with a url loads it into
+// #graph-detail over HTMX. Elements swapped in by HTMX are picked up on
+// htmx:load.
+
+(function () {
+	const SVG = "http://www.w3.org/2000/svg";
+	const WIDTH = 800;
+	const HEIGHT = 520;
+	const COLORS = {
+		skill: "#a855f7", // purple-500
+		project: "#3b82f6", // blue-500
+		category: "#ec4899", // pink-500
+	};
+
+	function el(name, attrs) {
+		const node = document.createElementNS(SVG, name);
+		for (const [k, v] of Object.entries(attrs)) node.setAttribute(k, v);
+		return node;
+	}
+
+	function radius(n) {
+		const base = n.kind === "category" ? 9 : 6;
+		return Math.min(base + 2 * Math.sqrt(n.weight), 22);
+	}
+
+	// Edge rest lengths: categories pull their skills in close, skills
+	// used together attract only weakly.
+	function restLength(e) {
+		if (e.source.kind === "category" || e.target.kind === "category") return 60;
+		if (e.source.kind === "skill" && e.target.kind === "skill") return 140;
+		return 90;
+	}
+
+	function tick(nodes, edges, alpha) {
+		for (let i = 0; i < nodes.length; i++) {
+			const a = nodes[i];
+			for (let j = i + 1; j < nodes.length; j++) {
+				const b = nodes[j];
+				let dx = b.x - a.x;
+				let dy = b.y - a.y;
+				let d2 = dx * dx + dy * dy;
+				if (d2 < 0.01) {
+					dx = Math.random() - 0.5;
+					dy = Math.random() - 0.5;
+					d2 = 0.01;
+				}
+				const f = (5000 * alpha) / d2;
+				const d = Math.sqrt(d2);
+				a.vx -= (dx / d) * f;
+				a.vy -= (dy / d) * f;
+				b.vx += (dx / d) * f;
+				b.vy += (dy / d) * f;
+			}
+		}
+		for (const e of edges) {
+			const dx = e.target.x - e.source.x;
+			const dy = e.target.y - e.source.y;
+			const d = Math.sqrt(dx * dx + dy * dy) || 1;
+			const strength = e.source.kind === "skill" && e.target.kind === "skill"
+				? 0.01 * e.weight
+				: 0.05;
+			const f = (d - restLength(e)) * strength * alpha;
+			e.source.vx += (dx / d) * f;
+			e.source.vy += (dy / d) * f;
+			e.target.vx -= (dx / d) * f;
+			e.target.vy -= (dy / d) * f;
+		}
+		for (const n of nodes) {
+			n.vx += (WIDTH / 2 - n.x) * 0.01 * alpha;
+			n.vy += (HEIGHT / 2 - n.y) * 0.01 * alpha;
+			if (n.fixed) {
+				n.vx = n.vy = 0;
+				continue;
+			}
+			n.vx *= 0.6;
+			n.vy *= 0.6;
+			const r = radius(n);
+			n.x = Math.max(r, Math.min(WIDTH - r, n.x + n.vx));
+			n.y = Math.max(r, Math.min(HEIGHT - r, n.y + n.vy));
+		}
+	}
+
+	function draw(container, graph) {
+		const byID = new Map();
+		const nodes = graph.nodes.map((n, i) => {
+			const angle = (i / graph.nodes.length) * 2 * Math.PI;
+			const node = {
+				...n,
+				x: WIDTH / 2 + Math.cos(angle) * 200,
+				y: HEIGHT / 2 + Math.sin(angle) * 160,
+				vx: 0,
+				vy: 0,
+				neighbours: new Set([n.id]),
+			};
+			byID.set(n.id, node);
+			return node;
+		});
+		const edges = graph.edges
+			.map((e) => ({ ...e, source: byID.get(e.source), target: byID.get(e.target) }))
+			.filter((e) => e.source && e.target);
+		for (const e of edges) {
+			e.source.neighbours.add(e.target.id);
+			e.target.neighbours.add(e.source.id);
+		}
+
+		const svg = el("svg", {
+			viewBox: `0 0 ${WIDTH} ${HEIGHT}`,
+			class: "w-full h-auto",
+			role: "img",
+			"aria-label": "Graph of skills, projects and categories",
+		});
+		const edgeGroup = el("g", {});
+		const nodeGroup = el("g", {});
+		svg.append(edgeGroup, nodeGroup);
+
+		for (const e of edges) {
+			const coUsed = e.source.kind === "skill" && e.target.kind === "skill";
+			e.line = el("line", {
+				stroke: coUsed ? "#6b21a8" : "#374151",
+				"stroke-width": Math.min(e.weight, 4),
+				"stroke-dasharray": coUsed ? "4 4" : "",
+			});
+			edgeGroup.append(e.line);
+		}
+		for (const n of nodes) {
+			n.group = el("g", { class: n.url ? "cursor-pointer" : "", tabindex: n.url ? 0 : -1 });
+			const title = el("title", {});
+			title.textContent = n.label;
+			n.circle = el("circle", { r: radius(n), fill: COLORS[n.kind] || "#9ca3af" });
+			n.text = el("text", {
+				"text-anchor": "middle",
+				dy: radius(n) + 12,
+				fill: n.kind === "project" ? "#93c5fd" : "#e5e7eb",
+				"font-size": n.kind === "category" ? 13 : 11,
+			});
+			n.text.textContent = n.label;
+			n.group.append(title, n.circle, n.text);
+			nodeGroup.append(n.group);
+		}
+
+		function render() {
+			for (const e of edges) {
+				e.line.setAttribute("x1", e.source.x);
+				e.line.setAttribute("y1", e.source.y);
+				e.line.setAttribute("x2", e.target.x);
+				e.line.setAttribute("y2", e.target.y);
+			}
+			for (const n of nodes) {
+				n.group.setAttribute("transform", `translate(${n.x},${n.y})`);
+			}
+		}
+
+		function highlight(focus) {
+			for (const n of nodes) {
+				n.group.style.opacity = !focus || focus.neighbours.has(n.id) ? 1 : 0.2;
+			}
+			for (const e of edges) {
+				const on = !focus || e.source === focus || e.target === focus;
+				e.line.style.opacity = on ? 1 : 0.1;
+			}
+		}
+
+		let alpha = 1;
+		let running = false;
+		function heat(to) {
+			alpha = Math.max(alpha, to);
+			if (running) return;
+			running = true;
+			requestAnimationFrame(function frame() {
+				tick(nodes, edges, alpha);
+				render();
+				alpha *= 0.98;
+				if (alpha > 0.01 && svg.isConnected) {
+					requestAnimationFrame(frame);
+				} else {
+					running = false;
+				}
+			});
+		}
+
+		// Dragging pins a node under the pointer; a press that barely
+		// moves counts as a click.
+		function toGraph(evt) {
+			const box = svg.getBoundingClientRect();
+			return {
+				x: ((evt.clientX - box.left) / box.width) * WIDTH,
+				y: ((evt.clientY - box.top) / box.height) * HEIGHT,
+			};
+		}
+		let dragging = null;
+		let moved = 0;
+		for (const n of nodes) {
+			n.group.addEventListener("pointerdown", (evt) => {
+				dragging = n;
+				moved = 0;
+				n.fixed = true;
+				svg.setPointerCapture(evt.pointerId);
+				evt.preventDefault();
+			});
+			n.group.addEventListener("mouseenter", () => dragging || highlight(n));
+			n.group.addEventListener("mouseleave", () => dragging || highlight(null));
+			n.group.addEventListener("keydown", (evt) => {
+				if (evt.key === "Enter" || evt.key === " ") select(n);
+			});
+		}
+		svg.addEventListener("pointermove", (evt) => {
+			if (!dragging) return;
+			const p = toGraph(evt);
+			moved += Math.abs(p.x - dragging.x) + Math.abs(p.y - dragging.y);
+			dragging.x = p.x;
+			dragging.y = p.y;
+			heat(0.3);
+			render();
+		});
+		svg.addEventListener("pointerup", () => {
+			if (!dragging) return;
+			const n = dragging;
+			dragging = null;
+			n.fixed = false;
+			if (moved < 4) select(n);
+		});
+
+		function select(n) {
+			highlight(n);
+			for (const other of nodes) {
+				other.circle.setAttribute("stroke", other === n ? "#fff" : "none");
+				other.circle.setAttribute("stroke-width", 2);
+			}
+			if (n.url && window.htmx) {
+				htmx.ajax("GET", n.url, { target: "#graph-detail", swap: "innerHTML" });
+			}
+		}
+
+		container.replaceChildren(svg);
+		render();
+		heat(1);
+	}
+
+	function init(root) {
+		root.querySelectorAll("[data-skill-graph]").forEach((container) => {
+			if (container.dataset.graphReady) return;
+			container.dataset.graphReady = "true";
+			fetch(container.dataset.skillGraph)
+				.then((res) => {
+					if (!res.ok) throw new Error(res.statusText);
+					return res.json();
+				})
+				.then((graph) => {
+					if (graph.nodes.length === 0) {
+						container.innerHTML =
+							'<p class="p-6 text-center text-gray-500">Nothing to show yet.</p>';
+						return;
+					}
+					draw(container, graph);
+				})
+				.catch(() => {
+					container.innerHTML =
+						'<p class="p-6 text-center text-gray-500">The graph failed to load.</p>';
+				});
+		});
+	}
+
+	document.addEventListener("DOMContentLoaded", () => init(document));
+	document.addEventListener("htmx:load", (evt) => init(evt.detail.elt));
+})();
@@ -0,0 +1,17 @@
+package components
+
+import (
+	"context"
+	"io"
+
+	"github.com/a-h/templ"
+	"github.com/yuin/goldmark"
+)
+
+// Markdown renders src as HTML. Raw HTML in the source is dropped, since
+// goldmark omits it unless the unsafe option is set.
+func Markdown(src string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return goldmark.Convert([]byte(src), w)
+	})
+}